@@ -0,0 +1,263 @@
+// Package charclass implements sets of runes stored as sorted ranges. They are
+// the predicates that label the class transitions of the dfa and nfa packages,
+// e.g. [a-z], \d, [^"] or \p{Greek}.
+package charclass
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Range is the runes from Lo to Hi inclusive
+type Range struct {
+	Lo, Hi rune
+}
+
+// Class is a set of runes as sorted, disjoint and non adjacent ranges. The
+// zero value is the empty class.
+type Class []Range
+
+// Any contains every rune
+var Any = Class{{0, unicode.MaxRune}}
+
+// Single returns the class containing only r
+func Single(r rune) Class {
+	return Class{{r, r}}
+}
+
+// Of returns the class containing the given runes
+func Of(runes ...rune) Class {
+	ranges := make([]Range, 0, len(runes))
+	for _, r := range runes {
+		ranges = append(ranges, Range{r, r})
+	}
+	return normalize(ranges)
+}
+
+// FromRanges returns the class containing the given, possibly overlapping, ranges
+func FromRanges(ranges ...Range) Class {
+	return normalize(append([]Range{}, ranges...))
+}
+
+// FromTable returns the class of the runes in a unicode range table
+func FromTable(table *unicode.RangeTable) Class {
+	ranges := make([]Range, 0, len(table.R16)+len(table.R32))
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			ranges = append(ranges, Range{lo, hi})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			ranges = append(ranges, Range{r, r})
+		}
+	}
+	for _, r := range table.R16 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return normalize(ranges)
+}
+
+// normalize sorts the ranges and merges the overlapping and adjacent ones
+func normalize(ranges []Range) Class {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Lo < ranges[j].Lo })
+	result := make(Class, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Lo > r.Hi {
+			continue
+		}
+		if last := len(result) - 1; last >= 0 && r.Lo <= result[last].Hi+1 {
+			if r.Hi > result[last].Hi {
+				result[last].Hi = r.Hi
+			}
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// IsEmpty reports whether the class contains no rune
+func (c Class) IsEmpty() bool {
+	return len(c) == 0
+}
+
+// Contains reports whether r is in the class
+func (c Class) Contains(r rune) bool {
+	i := sort.Search(len(c), func(i int) bool { return c[i].Hi >= r })
+	return i < len(c) && c[i].Lo <= r
+}
+
+// Size returns the number of runes in the class
+func (c Class) Size() int64 {
+	var size int64
+	for _, r := range c {
+		size += int64(r.Hi-r.Lo) + 1
+	}
+	return size
+}
+
+// Min returns the smallest rune of a non-empty class, used as the
+// representative of the class
+func (c Class) Min() rune {
+	return c[0].Lo
+}
+
+// Rune returns the only rune of the class, ok is false unless the class has exactly one rune
+func (c Class) Rune() (r rune, ok bool) {
+	if len(c) == 1 && c[0].Lo == c[0].Hi {
+		return c[0].Lo, true
+	}
+	return 0, false
+}
+
+// Equal reports whether both classes contain the same runes
+func (c Class) Equal(other Class) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for i := range c {
+		if c[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the runes in either class
+func (c Class) Union(other Class) Class {
+	return normalize(append(append([]Range{}, c...), other...))
+}
+
+// Complement returns the runes not in the class
+func (c Class) Complement() Class {
+	result := make(Class, 0, len(c)+1)
+	next := rune(0)
+	for _, r := range c {
+		if r.Lo > next {
+			result = append(result, Range{next, r.Lo - 1})
+		}
+		next = r.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		result = append(result, Range{next, unicode.MaxRune})
+	}
+	return result
+}
+
+// Intersect returns the runes in both classes
+func (c Class) Intersect(other Class) Class {
+	result := make(Class, 0)
+	i, j := 0, 0
+	for i < len(c) && j < len(other) {
+		lo, hi := max(c[i].Lo, other[j].Lo), min(c[i].Hi, other[j].Hi)
+		if lo <= hi {
+			result = append(result, Range{lo, hi})
+		}
+		if c[i].Hi < other[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Subtract returns the runes of the class that are not in other
+func (c Class) Subtract(other Class) Class {
+	return c.Intersect(other.Complement())
+}
+
+// Minterms partitions the union of the classes into the coarsest set of
+// disjoint, non-empty classes such that each of them is either inside or
+// outside of every given class. The result is sorted by smallest rune.
+func Minterms(classes []Class) []Class {
+	// every range boundary starts a new segment
+	bounds := make([]rune, 0)
+	for _, c := range classes {
+		for _, r := range c {
+			bounds = append(bounds, r.Lo, r.Hi+1)
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	// group the segments by the set of classes they belong to
+	groups := make(map[string]int)
+	result := make([]Class, 0)
+	for i := 0; i+1 < len(bounds); i++ {
+		lo, hi := bounds[i], bounds[i+1]-1
+		if lo > hi {
+			continue
+		}
+		var key strings.Builder
+		member := false
+		for _, c := range classes {
+			if c.Contains(lo) {
+				key.WriteByte('1')
+				member = true
+			} else {
+				key.WriteByte('0')
+			}
+		}
+		if !member {
+			continue
+		}
+		if index, exists := groups[key.String()]; exists {
+			result[index] = result[index].Union(Class{{lo, hi}})
+			continue
+		}
+		groups[key.String()] = len(result)
+		result = append(result, Class{{lo, hi}})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Min() < result[j].Min() })
+	return result
+}
+
+// Alphabet splits the alphabet made of the symbols and the classes into
+// minterms that also respect the labels, so that every symbol, class and label
+// is a union of them. Labels only split the alphabet, the runes they have
+// outside of it are left out. Every symbol that is a minterm on its own comes
+// first, in the given order, followed by the other minterms by smallest rune.
+// The smallest rune of a minterm can stand for all of it.
+func Alphabet(symbols []rune, classes []Class, labels []Class) []Class {
+	sigma := Of(symbols...)
+	parts := make([]Class, 0, len(symbols)+len(classes)+len(labels))
+	for _, symbol := range symbols {
+		parts = append(parts, Single(symbol))
+	}
+	for _, class := range classes {
+		sigma = sigma.Union(class)
+		parts = append(parts, class)
+	}
+	parts = append(parts, labels...)
+
+	single := make(map[rune]bool)
+	rest := make([]Class, 0)
+	for _, minterm := range Minterms(parts) {
+		if !sigma.Contains(minterm.Min()) {
+			continue
+		}
+		if r, ok := minterm.Rune(); ok {
+			single[r] = true
+			continue
+		}
+		rest = append(rest, minterm)
+	}
+
+	result := make([]Class, 0, len(single)+len(rest))
+	for _, symbol := range symbols {
+		if single[symbol] {
+			delete(single, symbol) // duplicated symbols appear once
+			result = append(result, Single(symbol))
+		}
+	}
+	// single rune minterms that are not symbols are sorted in with the others
+	for r := range single {
+		rest = append(rest, Single(r))
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].Min() < rest[j].Min() })
+	return append(result, rest...)
+}
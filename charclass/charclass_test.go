@@ -0,0 +1,113 @@
+package charclass
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		in      []rune
+		out     []rune
+	}{
+		{pattern: "[a-z]", in: []rune{'a', 'm', 'z'}, out: []rune{'A', '`', '{'}},
+		{pattern: `\d`, in: []rune{'0', '9'}, out: []rune{'a', '٣'}},
+		{pattern: `[^"]`, in: []rune{'a', '\n', 'λ', unicode.MaxRune}, out: []rune{'"'}},
+		{pattern: `[\w-]`, in: []rune{'_', '-', 'Z'}, out: []rune{' ', '.'}},
+		{pattern: `\p{Greek}`, in: []rune{'λ', 'Ω'}, out: []rune{'a', 'я'}},
+		{pattern: `\pL`, in: []rune{'a', 'я', '中'}, out: []rune{'1', ' '}},
+		{pattern: `\P{L}`, in: []rune{'1', ' '}, out: []rune{'a'}},
+		{pattern: `[\x{3bb}\]]`, in: []rune{'λ', ']'}, out: []rune{'\\'}},
+		{pattern: "[]a]", in: []rune{']', 'a'}, out: []rune{'['}},
+	}
+
+	for _, tc := range testCases {
+		class, err := Parse(tc.pattern)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error %v", tc.pattern, err)
+			continue
+		}
+		for _, r := range tc.in {
+			if !class.Contains(r) {
+				t.Errorf("Parse(%q).Contains(%q) = false; want true", tc.pattern, r)
+			}
+		}
+		for _, r := range tc.out {
+			if class.Contains(r) {
+				t.Errorf("Parse(%q).Contains(%q) = true; want false", tc.pattern, r)
+			}
+		}
+		// the rendering parses back to the same class
+		again, err := Parse(class.String())
+		if err != nil || !again.Equal(class) {
+			t.Errorf("Parse(%q).String() = %q does not parse back (%v)", tc.pattern, class.String(), err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		pos     int
+	}{
+		{pattern: "[abc", pos: 0},
+		{pattern: "[z-a]", pos: 3},
+		{pattern: `\p{Klingon}`, pos: 0},
+		{pattern: `\x{110000}`, pos: 0},
+		{pattern: `\d\d`, pos: 2},
+		{pattern: `[^\x{0}-\x{10ffff}]`, pos: 0},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.pattern)
+		classError, ok := err.(*Error)
+		if !ok {
+			t.Errorf("Parse(%q) error = %v; want an *Error", tc.pattern, err)
+			continue
+		}
+		if classError.Pos != tc.pos {
+			t.Errorf("Parse(%q) error at position %d; want %d (%v)", tc.pattern, classError.Pos, tc.pos, err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		class    Class
+		expected string
+	}{
+		{class: Single('a'), expected: "a"},
+		{class: Single('*'), expected: `\*`},
+		{class: FromRanges(Range{'a', 'c'}, Range{'x', 'y'}), expected: "[a-cxy]"},
+		{class: Digit, expected: `\d`},
+		{class: Word.Complement(), expected: `\W`},
+		{class: Single('"').Complement(), expected: `[^"]`},
+		{class: Of('-', '^', '\n'), expected: `[\n\-\^]`},
+		{class: Any, expected: `[\x{0}-\x{10ffff}]`},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.class.String(); got != tc.expected {
+			t.Errorf("%v.String() = %q; want %q", []Range(tc.class), got, tc.expected)
+		}
+		if parsed, err := Parse(tc.expected); err != nil || !parsed.Equal(tc.class) {
+			t.Errorf("Parse(%q) = %v, %v; want %v back", tc.expected, []Range(parsed), err, []Range(tc.class))
+		}
+	}
+}
+
+func TestAlphabet(t *testing.T) {
+	lower := FromRanges(Range{'a', 'z'})
+	alphabet := Alphabet([]rune{'x', '0'}, []Class{lower}, []Class{FromRanges(Range{'a', 'f'}), Digit})
+	expected := []Class{
+		Single('x'),
+		Single('0'),
+		FromRanges(Range{'a', 'f'}),
+		FromRanges(Range{'g', 'w'}, Range{'y', 'z'}),
+	}
+	if !reflect.DeepEqual(alphabet, expected) {
+		t.Errorf("Alphabet() = %v; want %v", alphabet, expected)
+	}
+}
@@ -0,0 +1,284 @@
+package charclass
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error reports a malformed class expression
+type Error struct {
+	Pos int // index of the offending rune
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Shorthand classes
+var (
+	Digit = FromRanges(Range{'0', '9'})
+	Word  = FromRanges(Range{'0', '9'}, Range{'A', 'Z'}, Range{'_', '_'}, Range{'a', 'z'})
+	Space = Of(' ', '\t', '\n', '\v', '\f', '\r')
+)
+
+// IsClass reports whether the string is a class expression rather than a
+// single rune: it starts with '[' or '\' and is longer than one rune
+func IsClass(s string) bool {
+	return len([]rune(s)) > 1 && (strings.HasPrefix(s, "[") || strings.HasPrefix(s, "\\"))
+}
+
+// Parse parses a whole class expression:
+//
+//	[abc] [a-z0-9] [^"]   sets, ranges and negated sets
+//	\d \w \s              digits, word characters and whitespace
+//	\D \W \S              their complements
+//	\p{L} \pL \p{Greek}   Unicode categories and scripts, \P{...} negated
+//	\n \t \r \f \v        control characters
+//	\x{3bb}               a code point in hexadecimal
+//	\x                    any other escaped rune is taken literally
+//
+// Escapes can also be used inside sets.
+func Parse(s string) (Class, error) {
+	pattern := []rune(s)
+	if len(pattern) == 0 {
+		return nil, &Error{Pos: 0, Msg: "empty class expression"}
+	}
+	class, pos, err := ParseAt(pattern, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(pattern) {
+		return nil, &Error{Pos: pos, Msg: "unexpected characters after the class"}
+	}
+	return class, nil
+}
+
+// ParseAt parses the set or escape starting at pattern[pos] and returns the
+// class with the position right after it. It is used by the regex parser.
+func ParseAt(pattern []rune, pos int) (Class, int, error) {
+	switch pattern[pos] {
+	case '[':
+		return parseSet(pattern, pos)
+	case '\\':
+		return parseEscape(pattern, pos)
+	}
+	return Single(pattern[pos]), pos + 1, nil
+}
+
+// parseSet parses [...] starting at the opening bracket
+func parseSet(pattern []rune, start int) (Class, int, error) {
+	pos := start + 1
+	negated := pos < len(pattern) && pattern[pos] == '^'
+	if negated {
+		pos++
+	}
+	ranges := make([]Range, 0)
+	first := true
+	for {
+		if pos >= len(pattern) {
+			return nil, 0, &Error{Pos: start, Msg: "missing ']'"}
+		}
+		if pattern[pos] == ']' && !first { // a ']' right after '[' or '[^' is a literal
+			pos++
+			break
+		}
+		first = false
+
+		low, next, err := parseItem(pattern, pos)
+		if err != nil {
+			return nil, 0, err
+		}
+		pos = next
+
+		// a range needs a single rune on both sides of the '-'
+		lo, single := low.Rune()
+		if single && pos+1 < len(pattern) && pattern[pos] == '-' && pattern[pos+1] != ']' {
+			high, next, err := parseItem(pattern, pos+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			hi, single := high.Rune()
+			if !single || hi < lo {
+				return nil, 0, &Error{Pos: pos + 1, Msg: "invalid class range"}
+			}
+			ranges = append(ranges, Range{lo, hi})
+			pos = next
+			continue
+		}
+		ranges = append(ranges, low...)
+	}
+	class := normalize(ranges)
+	if negated {
+		class = class.Complement()
+	}
+	if class.IsEmpty() {
+		return nil, 0, &Error{Pos: start, Msg: "empty character class"}
+	}
+	return class, pos, nil
+}
+
+// parseItem parses a rune or an escape inside a set
+func parseItem(pattern []rune, pos int) (Class, int, error) {
+	if pattern[pos] == '\\' {
+		return parseEscape(pattern, pos)
+	}
+	return Single(pattern[pos]), pos + 1, nil
+}
+
+// parseEscape parses an escape starting at the backslash
+func parseEscape(pattern []rune, start int) (Class, int, error) {
+	pos := start + 1
+	if pos >= len(pattern) {
+		return nil, 0, &Error{Pos: start, Msg: "trailing backslash"}
+	}
+	c := pattern[pos]
+	pos++
+	switch c {
+	case 'd':
+		return Digit, pos, nil
+	case 'D':
+		return Digit.Complement(), pos, nil
+	case 'w':
+		return Word, pos, nil
+	case 'W':
+		return Word.Complement(), pos, nil
+	case 's':
+		return Space, pos, nil
+	case 'S':
+		return Space.Complement(), pos, nil
+	case 'n':
+		return Single('\n'), pos, nil
+	case 't':
+		return Single('\t'), pos, nil
+	case 'r':
+		return Single('\r'), pos, nil
+	case 'f':
+		return Single('\f'), pos, nil
+	case 'v':
+		return Single('\v'), pos, nil
+	case 'x':
+		name, next, err := braced(pattern, start, pos)
+		if err != nil {
+			return nil, 0, err
+		}
+		code, err := strconv.ParseUint(name, 16, 32)
+		if err != nil || code > unicode.MaxRune {
+			return nil, 0, &Error{Pos: start, Msg: fmt.Sprintf("invalid code point %q", name)}
+		}
+		return Single(rune(code)), next, nil
+	case 'p', 'P':
+		name, next := "", pos
+		if pos < len(pattern) && pattern[pos] != '{' {
+			name, next = string(pattern[pos]), pos+1 // one letter form, e.g. \pL
+		} else {
+			var err error
+			if name, next, err = braced(pattern, start, pos); err != nil {
+				return nil, 0, err
+			}
+		}
+		table := unicode.Categories[name]
+		if table == nil {
+			table = unicode.Scripts[name]
+		}
+		if table == nil {
+			return nil, 0, &Error{Pos: start, Msg: fmt.Sprintf("unknown Unicode category or script %q", name)}
+		}
+		class := FromTable(table)
+		if c == 'P' {
+			class = class.Complement()
+		}
+		return class, next, nil
+	}
+	return Single(c), pos, nil
+}
+
+// braced returns the text between the braces starting at pattern[pos]
+func braced(pattern []rune, start int, pos int) (string, int, error) {
+	if pos >= len(pattern) || pattern[pos] != '{' {
+		return "", 0, &Error{Pos: start, Msg: "missing '{' after escape"}
+	}
+	for end := pos + 1; end < len(pattern); end++ {
+		if pattern[end] == '}' {
+			return string(pattern[pos+1 : end]), end + 1, nil
+		}
+	}
+	return "", 0, &Error{Pos: start, Msg: "missing '}' after escape"}
+}
+
+// String renders the class in the syntax accepted by Parse, using a
+// shorthand or a negated set when that is shorter. A single rune is
+// rendered on its own, escaped if it is a meta character.
+func (c Class) String() string {
+	if r, ok := c.Rune(); ok {
+		var b strings.Builder
+		writeRune(&b, r, `\[]()|*+?`)
+		return b.String()
+	}
+	for _, shorthand := range []struct {
+		class Class
+		name  string
+	}{{Digit, `\d`}, {Word, `\w`}, {Space, `\s`}} {
+		if c.Equal(shorthand.class) {
+			return shorthand.name
+		}
+		if c.Equal(shorthand.class.Complement()) {
+			return `\` + strings.ToUpper(shorthand.name[1:])
+		}
+	}
+
+	var b strings.Builder
+	complement := c.Complement()
+	if complement.IsEmpty() {
+		return `[\x{0}-\x{10ffff}]` // [^] does not parse
+	}
+	if len(complement) < len(c) || (len(c) > 0 && c[len(c)-1].Hi == unicode.MaxRune) {
+		b.WriteString("[^")
+		writeRanges(&b, complement)
+	} else {
+		b.WriteByte('[')
+		if c.IsEmpty() {
+			return `[^\x{0}-\x{10ffff}]`
+		}
+		writeRanges(&b, c)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func writeRanges(b *strings.Builder, class Class) {
+	const meta = `\[]^-`
+	for _, r := range class {
+		writeRune(b, r.Lo, meta)
+		switch {
+		case r.Hi == r.Lo:
+		case r.Hi == r.Lo+1:
+			writeRune(b, r.Hi, meta)
+		default:
+			b.WriteByte('-')
+			writeRune(b, r.Hi, meta)
+		}
+	}
+}
+
+// writeRune writes r, escaping meta characters and writing control and other
+// non printable runes as \x{...}
+func writeRune(b *strings.Builder, r rune, meta string) {
+	switch {
+	case r == '\n':
+		b.WriteString(`\n`)
+	case r == '\t':
+		b.WriteString(`\t`)
+	case r == '\r':
+		b.WriteString(`\r`)
+	case !unicode.IsPrint(r):
+		fmt.Fprintf(b, `\x{%x}`, r)
+	case strings.ContainsRune(meta, r):
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	default:
+		b.WriteRune(r)
+	}
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+	"github.com/dekuu5/FiniteStateMachine/nfa"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// stringList is a flag that can be given several times
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ", ") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// invalid logs the error and returns the exit code of an invalid command
+func invalid(err error) int {
+	log.Print(err)
+	return exitInvalid
+}
+
+// runValidate prints every validation problem of an automaton file,
+// e.g. validate -file automaton.json -format json -strict
+func runValidate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	filePath := flags.String("file", "", "Path to the JSON file containing the automaton")
+	automatonType := flags.String("type", "dfa", "Type of the automaton (dfa or nfa)")
+	format := flags.String("format", "table", "Format of the report (table or json)")
+	strict := flags.Bool("strict", false, "Reject unknown fields, missing fields and invalid UTF-8 in the file")
+	flags.Parse(args)
+	if *filePath == "" {
+		return invalid(fmt.Errorf("please provide the path to the JSON file using the -file flag"))
+	}
+
+	var report *utils.Report
+	switch strings.ToLower(*automatonType) {
+	case "dfa":
+		load := utils.LoadJson
+		if *strict {
+			load = utils.LoadJsonStrict
+		}
+		automatonJson, err := load(*filePath)
+		if err != nil {
+			return invalid(fmt.Errorf("error loading automaton: %v", err))
+		}
+		report = dfa.ValidateDfaReport(automatonJson)
+	case "nfa":
+		load := utils.LoadJsonNfa
+		if *strict {
+			load = utils.LoadJsonNfaStrict
+		}
+		automatonJson, err := load(*filePath)
+		if err != nil {
+			return invalid(fmt.Errorf("error loading automaton: %v", err))
+		}
+		report = nfa.ValidateNfaReport(automatonJson)
+	default:
+		return invalid(fmt.Errorf("unknown automaton type: %s", *automatonType))
+	}
+
+	var err error
+	switch strings.ToLower(*format) {
+	case "table":
+		err = report.WriteTable(os.Stdout)
+	case "json":
+		err = report.WriteJSON(os.Stdout)
+	default:
+		return invalid(fmt.Errorf("unknown report format: %s", *format))
+	}
+	if err != nil {
+		return invalid(fmt.Errorf("error writing the report: %v", err))
+	}
+	if !report.Valid() {
+		return exitInvalid
+	}
+	return exitAccepted
+}
+
+// runRun prints whether the automaton accepts every input string, taken from
+// -input, from the lines of -inputs or from the lines of stdin,
+// e.g. run -file automaton.json -input ab -input ba
+func runRun(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	var inputs stringList
+	flags.Var(&inputs, "input", "Input string to run, can be given several times")
+	inputFile := flags.String("inputs", "", "Path to a file with one input string per line, - for stdin")
+	traceFormat := flags.String("trace", "", "Print the states entered at every step as a table or as json")
+	stream := flags.String("stream", "", "Path to a file matched as a single input without reading it into memory, - for stdin")
+	stopWhenDead := flags.Bool("stop-when-dead", false, "Stop reading -stream once no continuation can be accepted")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	if *stream != "" {
+		return runStream(automaton, *stream, dfa.MatchOptions{StopWhenDead: *stopWhenDead})
+	}
+	switch strings.ToLower(*traceFormat) {
+	case "", "table", "json":
+	default:
+		return invalid(fmt.Errorf("unknown trace format: %s", *traceFormat))
+	}
+	if len(inputs) == 0 && *inputFile == "" {
+		*inputFile = "-"
+	}
+	if *inputFile != "" {
+		lines, err := readLines(*inputFile)
+		if err != nil {
+			return invalid(err)
+		}
+		inputs = append(inputs, lines...)
+	}
+
+	tokens := tokensOf(automaton)
+	code := exitAccepted
+	for _, input := range inputs {
+		symbols, known, err := readSymbols(input, tokens)
+		if err != nil {
+			fmt.Printf("%q: invalid (%v)\n", input, err)
+			code = exitInvalid
+			continue
+		}
+		accepted := false
+		switch {
+		case !known:
+			fmt.Printf("%q: rejected (unknown token)\n", input)
+		case *traceFormat != "":
+			trace := traceRun(automaton, symbols)
+			if err := writeTrace(trace, *traceFormat); err != nil {
+				return invalid(fmt.Errorf("error writing the trace: %v", err))
+			}
+			accepted = trace.Accepted
+		default:
+			accepted = accepts(automaton, symbols)
+			verdict := "rejected"
+			if accepted {
+				verdict = "accepted"
+			}
+			fmt.Printf("%q: %s\n", input, verdict)
+		}
+		if !accepted && code == exitAccepted {
+			code = exitRejected
+		}
+	}
+	return code
+}
+
+// runStream matches the whole file, or stdin for -, as a single input
+func runStream(automaton dfa.Automaton, filePath string, options dfa.MatchOptions) int {
+	if tokensOf(automaton) != nil {
+		return invalid(fmt.Errorf("-stream reads text and cannot be used with a token alphabet"))
+	}
+	var reader io.Reader = os.Stdin
+	if filePath != "-" {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return invalid(fmt.Errorf("error reading the stream: %v", err))
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	matcher := automaton.(interface {
+		MatchReader(r io.Reader, options dfa.MatchOptions) (dfa.MatchResult, error)
+	})
+	result, err := matcher.MatchReader(reader, options)
+	if err != nil {
+		return invalid(fmt.Errorf("error reading the stream after %d bytes: %v", result.Bytes, err))
+	}
+	switch {
+	case result.Accepted:
+		fmt.Printf("%s: accepted (%d bytes, %d runes)\n", filePath, result.Bytes, result.Runes)
+		return exitAccepted
+	case result.Dead && options.StopWhenDead:
+		fmt.Printf("%s: rejected, dead after %d bytes, %d runes\n", filePath, result.Bytes, result.Runes)
+	default:
+		fmt.Printf("%s: rejected (%d bytes, %d runes)\n", filePath, result.Bytes, result.Runes)
+	}
+	return exitRejected
+}
+
+// traceRun records the run of a DFA or of the set simulation of an NFA
+func traceRun(automaton dfa.Automaton, symbols []rune) *utils.Trace {
+	if a, ok := automaton.(*nfa.NFA); ok {
+		return a.Trace(symbols)
+	}
+	return automaton.ToDFA().Trace(symbols)
+}
+
+// writeTrace prints the trace as a table or as json
+func writeTrace(trace *utils.Trace, format string) error {
+	if strings.ToLower(format) == "json" {
+		return trace.WriteJSON(os.Stdout)
+	}
+	return trace.WriteTable(os.Stdout)
+}
+
+// readLines returns the lines of the file, or of stdin for -
+func readLines(filePath string) ([]string, error) {
+	var reader io.Reader = os.Stdin
+	if filePath != "-" {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading inputs: %v", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading inputs: %v", err)
+	}
+	return lines, nil
+}
+
+// runTest runs every case of a test suite through the automaton and prints the
+// failing cases with a summary, e.g. test -file automaton.json -suite cases.txt -junit report.xml
+func runTest(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	suitePath := flags.String("suite", "", "Path to the test cases, a JSON array or lines of +accepted and -rejected inputs")
+	junitPath := flags.String("junit", "", "Path to write a JUnit XML report to")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	if *suitePath == "" {
+		return invalid(fmt.Errorf("please provide the test cases using the -suite flag"))
+	}
+	cases, err := utils.LoadSuite(*suitePath)
+	if err != nil {
+		return invalid(err)
+	}
+
+	tokens := tokensOf(automaton)
+	suite := utils.SuiteResult{Name: *suitePath}
+	for _, testCase := range cases {
+		result := utils.CaseResult{Case: testCase}
+		symbols, known, err := readSymbols(testCase.Input, tokens)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Accepted = known && accepts(automaton, symbols)
+		}
+		suite.Results = append(suite.Results, result)
+	}
+
+	if err := suite.WriteSummary(os.Stdout); err != nil {
+		return invalid(err)
+	}
+	if *junitPath != "" {
+		file, err := os.Create(*junitPath)
+		if err != nil {
+			return invalid(fmt.Errorf("error writing the JUnit report: %v", err))
+		}
+		defer file.Close()
+		if err := suite.WriteJUnit(file); err != nil {
+			return invalid(fmt.Errorf("error writing the JUnit report: %v", err))
+		}
+	}
+	if len(suite.Failed()) > 0 {
+		return exitRejected
+	}
+	return exitAccepted
+}
+
+// runConvert writes the automaton as JSON, determinized unless -to nfa is given,
+// e.g. convert -regex '(a|b)*abb' -to dfa
+func runConvert(args []string) int {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	to := flags.String("to", "dfa", "Type of the result (dfa, or nfa for an NFA or a regular expression)")
+	format := flags.String("format", "json", "Output format (json or dot)")
+	trim := flags.Bool("trim", false, "Remove the unreachable and dead states")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	switch strings.ToLower(*to) {
+	case "dfa":
+		automaton = automaton.ToDFA()
+	case "nfa":
+		if _, ok := automaton.(*nfa.NFA); !ok {
+			return invalid(fmt.Errorf("only an NFA or a regular expression can be written as an NFA"))
+		}
+	default:
+		return invalid(fmt.Errorf("unknown automaton type: %s", *to))
+	}
+	if *trim {
+		automaton = trimAutomaton(automaton)
+	}
+	if err := writeAutomaton(os.Stdout, automaton, *format); err != nil {
+		return invalid(fmt.Errorf("error writing the automaton: %v", err))
+	}
+	return exitAccepted
+}
+
+// runMinimize writes the minimal DFA of the automaton,
+// e.g. minimize -type nfa -file automaton.json -format dot
+func runMinimize(args []string) int {
+	flags := flag.NewFlagSet("minimize", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	format := flags.String("format", "json", "Output format (json or dot)")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	minimal, _ := automaton.ToDFA().Minimize()
+	if err := writeAutomaton(os.Stdout, minimal, *format); err != nil {
+		return invalid(fmt.Errorf("error writing the automaton: %v", err))
+	}
+	return exitAccepted
+}
+
+// runEquiv checks whether two automata accept the same language and prints a
+// counterexample if not, e.g. equiv -file a.json -other b.json -other-type nfa
+func runEquiv(args []string) int {
+	flags := flag.NewFlagSet("equiv", flag.ExitOnError)
+	var first source
+	first.register(flags)
+	var second source
+	flags.StringVar(&second.file, "other", "", "Path to the JSON file containing the second automaton")
+	flags.StringVar(&second.automatonType, "other-type", "", "Type of the second automaton (dfa or nfa), defaults to -type")
+	flags.StringVar(&second.pattern, "other-regex", "", "Regular expression to compile into the second automaton instead of reading -other")
+	flags.Parse(args)
+	if second.automatonType == "" {
+		second.automatonType = first.automatonType
+	}
+	if second.file == "" && second.pattern == "" {
+		return invalid(fmt.Errorf("please provide the second automaton using the -other flag or a pattern using the -other-regex flag"))
+	}
+
+	a, err := first.load()
+	if err != nil {
+		return invalid(err)
+	}
+	b, err := second.load()
+	if err != nil {
+		return invalid(err)
+	}
+
+	// the counterexample is named with the tokens of both automata
+	left, right, err := dfa.ShareTokens(a.ToDFA(), b.ToDFA())
+	if err != nil {
+		return invalid(err)
+	}
+	equivalent, counterexample := dfa.Equivalent(left, right)
+	if equivalent {
+		fmt.Println("The automata are equivalent")
+		return exitAccepted
+	}
+	accepted, rejected := "first", "second"
+	if !left.ValidateString(counterexample) {
+		accepted, rejected = rejected, accepted
+	}
+	fmt.Printf("The automata are not equivalent: %q is accepted by the %s automaton and rejected by the %s\n", left.Tokens.Format(counterexample), accepted, rejected)
+	return exitRejected
+}
+
+// runRender writes the automaton in Graphviz dot format,
+// e.g. render -regex 'a(b|c)*' | dot -Tsvg
+func runRender(args []string) int {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	trim := flags.Bool("trim", false, "Remove the unreachable and dead states")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	if *trim {
+		automaton = trimAutomaton(automaton)
+	}
+	if err := writeAutomaton(os.Stdout, automaton, "dot"); err != nil {
+		return invalid(fmt.Errorf("error writing the automaton: %v", err))
+	}
+	return exitAccepted
+}
+
+// runEnumerate prints the first strings accepted by an automaton in shortlex order,
+// or its shortest accepted and rejected strings, e.g. enumerate -file automaton.json -count 50
+func runEnumerate(args []string) int {
+	flags := flag.NewFlagSet("enumerate", flag.ExitOnError)
+	var s source
+	s.register(flags)
+	count := flags.Int("count", 50, "Number of strings to print, negative for no limit")
+	maxLength := flags.Int("max-length", -1, "Length of the longest string to print, negative for no limit")
+	shortestAccepted := flags.Bool("shortest-accepted", false, "Print the shortest accepted string instead")
+	shortestRejected := flags.Bool("shortest-rejected", false, "Print the shortest rejected string instead")
+	from := flags.String("from", "", "State to start -shortest-accepted and -shortest-rejected from, defaults to the start state")
+	flags.Parse(args)
+
+	automaton, err := s.load()
+	if err != nil {
+		return invalid(err)
+	}
+	if *shortestAccepted || *shortestRejected {
+		return printShortest(automaton, *from, *shortestAccepted, *shortestRejected)
+	}
+
+	enumerator := automaton.ToDFA().Enumerate(*maxLength, *count)
+	for word, ok := enumerator.Next(); ok; word, ok = enumerator.Next() {
+		fmt.Printf("%q\n", tokensOf(automaton).Format(word))
+	}
+	return exitAccepted
+}
+
+// printShortest prints the shortest accepted and rejected strings starting from the given state
+func printShortest(automaton dfa.Automaton, from string, accepted bool, rejected bool) int {
+	var states []string
+	switch a := automaton.(type) {
+	case *dfa.DFA:
+		states = a.States
+	case *nfa.NFA:
+		states = a.States
+	}
+	if from != "" && !slices.Contains(states, from) {
+		return invalid(fmt.Errorf("unknown state: %s", from))
+	}
+
+	queries := automaton.(interface {
+		ShortestAccepted(state string) ([]rune, bool)
+		ShortestRejected(state string) ([]rune, bool)
+	})
+	if accepted {
+		if word, found := queries.ShortestAccepted(from); found {
+			fmt.Printf("Shortest accepted string: %q\n", tokensOf(automaton).Format(word))
+		} else {
+			fmt.Println("No string is accepted")
+		}
+	}
+	if rejected {
+		if word, found := queries.ShortestRejected(from); found {
+			fmt.Printf("Shortest rejected string: %q\n", tokensOf(automaton).Format(word))
+		} else {
+			fmt.Println("No string is rejected")
+		}
+	}
+	return exitAccepted
+}
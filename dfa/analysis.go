@@ -0,0 +1,71 @@
+package dfa
+
+import (
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// Analyze finds the states of the DFA that cannot be reached from the start
+// state and the ones that cannot reach an accepting state.
+func (dfaTree *DFA) Analyze() utils.Analysis {
+	return utils.Analyze(dfaTree.States, dfaTree.successors(), dfaTree.StartState.StateName, dfaTree.AcceptStates)
+}
+
+// Trim returns the DFA without its unreachable and dead states. The start
+// state is always kept, so a DFA accepting nothing is trimmed down to it.
+// Transitions into removed states are dropped, which usually makes the result
+// partial.
+func (dfaTree *DFA) Trim() *DFA {
+	useless := dfaTree.Analyze().Useless()
+	start := dfaTree.StartState.StateName
+	keep := func(state string) bool { return state == start || !useless[state] }
+
+	states := make([]string, 0, len(dfaTree.States))
+	transitions := make(map[string]map[rune]string)
+	classTransitions := make(map[string][]ClassTransition)
+	for _, state := range dfaTree.States {
+		if !keep(state) {
+			continue
+		}
+		states = append(states, state)
+		transitions[state] = make(map[rune]string)
+		for symbol, target := range dfaTree.Transitions[state] {
+			if keep(target) {
+				transitions[state][symbol] = target
+			}
+		}
+		for _, edge := range dfaTree.ClassTransitions[state] {
+			if keep(edge.Target) {
+				classTransitions[state] = append(classTransitions[state], edge)
+			}
+		}
+	}
+	acceptStates := make([]string, 0, len(dfaTree.AcceptStates))
+	for _, state := range dfaTree.AcceptStates {
+		if keep(state) {
+			acceptStates = append(acceptStates, state)
+		}
+	}
+
+	symbols := append([]rune{}, dfaTree.Symbols...)
+	classes := append([]charclass.Class{}, dfaTree.Classes...)
+	trimmed := NewSymbolic(states, symbols, classes, transitions, classTransitions, start, acceptStates)
+	trimmed.Tokens = dfaTree.Tokens
+	return trimmed
+}
+
+// successors lists the targets of the transitions of every state
+func (dfaTree *DFA) successors() map[string][]string {
+	successors := make(map[string][]string, len(dfaTree.States))
+	for state, transitions := range dfaTree.Transitions {
+		for _, target := range transitions {
+			successors[state] = append(successors[state], target)
+		}
+	}
+	for state, edges := range dfaTree.ClassTransitions {
+		for _, edge := range edges {
+			successors[state] = append(successors[state], edge.Target)
+		}
+	}
+	return successors
+}
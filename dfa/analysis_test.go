@@ -0,0 +1,67 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestAnalyzeAndTrim(t *testing.T) {
+	automata := utils.FiniteAutomata{
+		States:       []string{"s", "t", "trap", "orphan", "lost"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "s",
+		AcceptStates: []string{"t"},
+		Transitions: map[string]map[string]string{
+			"s":      {"a": "t", "b": "trap"},
+			"t":      {"a": "t", "b": "trap"},
+			"trap":   {"a": "trap", "b": "trap"},
+			"orphan": {"a": "t", "b": "s"},
+			"lost":   {"a": "lost", "b": "trap"},
+		},
+	}
+
+	dfaTree := Constructor(automata)
+	analysis := dfaTree.Analyze()
+	if !reflect.DeepEqual(analysis.Unreachable, []string{"orphan", "lost"}) {
+		t.Errorf("Analyze().Unreachable = %v; want [orphan lost]", analysis.Unreachable)
+	}
+	if !reflect.DeepEqual(analysis.Dead, []string{"trap", "lost"}) {
+		t.Errorf("Analyze().Dead = %v; want [trap lost]", analysis.Dead)
+	}
+	if both := analysis.Both(); !reflect.DeepEqual(both, []string{"lost"}) {
+		t.Errorf("Analyze().Both() = %v; want [lost]", both)
+	}
+
+	report := ValidateDfaReport(automata)
+	codes := make(map[string]string)
+	for _, issue := range report.Issues {
+		if issue.Severity != utils.SeverityWarning {
+			t.Errorf("issue %s has severity %s; want warning", issue.Code, issue.Severity)
+		}
+		codes[issue.State] = issue.Code
+	}
+	expected := map[string]string{
+		"orphan": utils.CodeUnreachableState,
+		"trap":   utils.CodeDeadState,
+		"lost":   utils.CodeUselessState,
+	}
+	if !reflect.DeepEqual(codes, expected) || !report.Valid() {
+		t.Errorf("ValidateDfaReport() = %+v; want warnings %v", report.Issues, expected)
+	}
+
+	trimmed := dfaTree.Trim()
+	if !reflect.DeepEqual(trimmed.States, []string{"s", "t"}) {
+		t.Errorf("Trim().States = %v; want [s t]", trimmed.States)
+	}
+	if trimmed.IsComplete() {
+		t.Errorf("Trim() is complete; want the transitions into trap removed")
+	}
+	if equivalent, counterexample := Equivalent(dfaTree, trimmed); !equivalent {
+		t.Errorf("Trim() differs on %q", string(counterexample))
+	}
+	if analysis := trimmed.Analyze(); len(analysis.Unreachable)+len(analysis.Dead) != 0 {
+		t.Errorf("Trim().Analyze() = %+v; want nothing left", analysis)
+	}
+}
@@ -0,0 +1,113 @@
+package dfa
+
+import (
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+)
+
+// ClassTransition is a transition taken on every rune of a character class
+type ClassTransition struct {
+	Class  charclass.Class
+	Target string
+}
+
+// ClassEdge is a class transition of the node graph
+type ClassEdge struct {
+	Class  charclass.Class
+	Target *StateNode
+}
+
+// Next returns the node reached from the node on the symbol, nil when there is
+// no transition. Transitions on single symbols are tried before classes.
+func (node *StateNode) Next(symbol rune) *StateNode {
+	if next, exists := node.Transitions[symbol]; exists {
+		return next
+	}
+	for _, edge := range node.ClassTransitions {
+		if edge.Class.Contains(symbol) {
+			return edge.Target
+		}
+	}
+	return nil
+}
+
+// next returns the target of the transition of the state on the symbol
+func (dfaTree *DFA) next(state string, symbol rune) (string, bool) {
+	if target, exists := dfaTree.Transitions[state][symbol]; exists {
+		return target, true
+	}
+	for _, edge := range dfaTree.ClassTransitions[state] {
+		if edge.Class.Contains(symbol) {
+			return edge.Target, true
+		}
+	}
+	return "", false
+}
+
+// NewFromAlphabet builds a DFA over an alphabet of disjoint classes, as
+// returned by charclass.Alphabet, from a transition table keyed by the
+// smallest rune of every class. Classes of a single rune become ordinary
+// symbols, the other classes leading from a state to the same target are
+// merged into one class transition.
+func NewFromAlphabet(states []string, alphabet []charclass.Class, transitions map[string]map[rune]string, startState string, acceptStates []string) *DFA {
+	symbols := make([]rune, 0, len(alphabet))
+	classes := make([]charclass.Class, 0)
+	for _, class := range alphabet {
+		if r, ok := class.Rune(); ok {
+			symbols = append(symbols, r)
+		} else {
+			classes = append(classes, class)
+		}
+	}
+
+	literal := make(map[string]map[rune]string, len(transitions))
+	classTransitions := make(map[string][]ClassTransition)
+	for state, row := range transitions {
+		literal[state] = make(map[rune]string, len(row))
+		merged := make(map[string]charclass.Class)
+		targets := make([]string, 0)
+		for _, class := range alphabet {
+			target, exists := row[class.Min()]
+			if !exists {
+				continue
+			}
+			if r, ok := class.Rune(); ok {
+				literal[state][r] = target
+				continue
+			}
+			if _, seen := merged[target]; !seen {
+				targets = append(targets, target)
+			}
+			merged[target] = merged[target].Union(class)
+		}
+		for _, target := range targets {
+			classTransitions[state] = append(classTransitions[state], ClassTransition{Class: merged[target], Target: target})
+		}
+	}
+
+	return NewSymbolic(states, symbols, classes, literal, classTransitions, startState, acceptStates)
+}
+
+// alphabet splits the alphabet of the DFA into disjoint classes, see
+// charclass.Alphabet. For a DFA without classes it is one class per symbol in
+// the order of Symbols.
+func (dfaTree *DFA) alphabet() []charclass.Class {
+	return charclass.Alphabet(dfaTree.Symbols, dfaTree.Classes, dfaTree.labels())
+}
+
+// sharedAlphabet splits the union of the alphabets of both DFAs into disjoint
+// classes, the single symbols coming first in rune order
+func sharedAlphabet(a, b *DFA) []charclass.Class {
+	classes := append(append([]charclass.Class{}, a.Classes...), b.Classes...)
+	return charclass.Alphabet(unionSymbols(a.Symbols, b.Symbols), classes, append(a.labels(), b.labels()...))
+}
+
+// labels returns the classes of every class transition
+func (dfaTree *DFA) labels() []charclass.Class {
+	labels := make([]charclass.Class, 0)
+	for _, state := range dfaTree.States {
+		for _, edge := range dfaTree.ClassTransitions[state] {
+			labels = append(labels, edge.Class)
+		}
+	}
+	return labels
+}
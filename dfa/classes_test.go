@@ -0,0 +1,120 @@
+package dfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// identifiers: a lowercase letter or '_' followed by letters, digits and '_'
+var identifier = utils.FiniteAutomata{
+	States:       []string{"start", "ident", "error"},
+	Symbols:      []string{"[a-z]", `\d`, "_"},
+	StartState:   "start",
+	AcceptStates: []string{"ident"},
+	Transitions: map[string]map[string]string{
+		"start": {"[a-z]": "ident", "_": "ident", `\d`: "error"},
+		"ident": {"[a-z0-9_]": "ident"},
+		"error": {"[a-z]": "error", `\d`: "error", "_": "error"},
+	},
+}
+
+func TestClassTransitions(t *testing.T) {
+	if report := ValidateDfaReport(identifier); !report.Valid() {
+		t.Fatalf("ValidateDfaReport() = %+v; want a valid DFA", report.Issues)
+	}
+	dfaTree := Constructor(identifier)
+	minimized, _ := dfaTree.Minimize()
+	complement := dfaTree.Complement()
+
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "x", expected: true},
+		{input: "foo_42", expected: true},
+		{input: "_", expected: true},
+		{input: "", expected: false},
+		{input: "1a", expected: false},
+		{input: "Foo", expected: false},
+		{input: "a-b", expected: false},
+	}
+	for _, tc := range testCases {
+		input := []rune(tc.input)
+		if got := dfaTree.ValidateString(input); got != tc.expected {
+			t.Errorf("ValidateString(%q) = %v; want %v", tc.input, got, tc.expected)
+		}
+		if got := minimized.ValidateString(input); got != tc.expected {
+			t.Errorf("Minimize().ValidateString(%q) = %v; want %v", tc.input, got, tc.expected)
+		}
+		// the complement only accepts strings over the alphabet
+		outside := tc.input == "Foo" || tc.input == "a-b"
+		if got := complement.ValidateString(input); got != (!tc.expected && !outside) {
+			t.Errorf("Complement().ValidateString(%q) = %v; want %v", tc.input, got, !tc.expected && !outside)
+		}
+	}
+
+	if len(minimized.States) != 3 || len(minimized.ClassTransitions["ident"]) != 1 {
+		t.Errorf("Minimize() = %v, %v; want the DFA unchanged", minimized.States, minimized.ClassTransitions)
+	}
+
+	// the same language spelled out one rune at a time
+	symbols := make([]rune, 0)
+	for r := 'a'; r <= 'z'; r++ {
+		symbols = append(symbols, r)
+	}
+	for r := '0'; r <= '9'; r++ {
+		symbols = append(symbols, r)
+	}
+	symbols = append(symbols, '_')
+	transitions := map[string]map[rune]string{"start": {}, "ident": {}}
+	for _, symbol := range symbols {
+		if symbol < '0' || symbol > '9' {
+			transitions["start"][symbol] = "ident"
+		}
+		transitions["ident"][symbol] = "ident"
+	}
+	expanded := New([]string{"start", "ident"}, symbols, transitions, "start", []string{"ident"})
+	if equivalent, counterexample := Equivalent(dfaTree, expanded); !equivalent {
+		t.Errorf("Equivalent(classes, expanded) = false, %q; want true", string(counterexample))
+	}
+
+	delete(transitions["start"], 'q')
+	expanded = New([]string{"start", "ident"}, symbols, transitions, "start", []string{"ident"})
+	if equivalent, counterexample := Equivalent(dfaTree, expanded); equivalent || string(counterexample) != "q" {
+		t.Errorf("Equivalent(classes, expanded without q) = %v, %q; want false, \"q\"", equivalent, string(counterexample))
+	}
+
+	// the classes survive a round trip through the JSON representation
+	if equivalent, _ := Equivalent(dfaTree, Constructor(minimized.ToFiniteAutomata())); !equivalent {
+		t.Errorf("Constructor(Minimize().ToFiniteAutomata()) is not equivalent to the DFA")
+	}
+}
+
+func TestClassValidation(t *testing.T) {
+	report := ValidateDfaReport(utils.FiniteAutomata{
+		States:       []string{"s", "t"},
+		Symbols:      []string{"[a-z]", "[z-a]"},
+		StartState:   "s",
+		AcceptStates: []string{"t"},
+		Transitions: map[string]map[string]string{
+			"s": {"[a-m]": "t", "[k-z]": "s"},
+			"t": {"[a-y]": "t", `\d`: "s"},
+		},
+	})
+
+	expected := []utils.Issue{
+		{Code: utils.CodeInvalidClass, Symbol: "[z-a]"},
+		{Code: utils.CodeOverlappingLabels, State: "s", Symbol: "[k-z]"},
+		{Code: utils.CodeIncompleteTransition, State: "t"},
+		{Code: utils.CodeUnknownSymbol, State: "t", Symbol: `\d`},
+	}
+	if len(report.Issues) != len(expected) {
+		t.Fatalf("ValidateDfaReport() issues = %+v; want %+v", report.Issues, expected)
+	}
+	for i, issue := range report.Issues {
+		if issue.Code != expected[i].Code || issue.State != expected[i].State || issue.Symbol != expected[i].Symbol {
+			t.Errorf("ValidateDfaReport() issue %d = %+v; want %+v", i, issue, expected[i])
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package dfa
+
+import (
+	"math"
+	"math/big"
+)
+
+// powerIterations bounds the steps of the power iteration of GrowthRate
+const powerIterations = 10000
+
+// weightedEdge leads to target on weight different symbols
+type weightedEdge struct {
+	target string
+	weight int64
+}
+
+// CountByLength returns the number of strings of every length from 0 to n
+// accepted by the DFA, counts[k] being the count for length k. A character
+// class transition counts once for every rune of the class. The result is
+// empty when n is negative.
+func (dfaTree *DFA) CountByLength(n int) []*big.Int {
+	if n < 0 {
+		return []*big.Int{}
+	}
+	edges := dfaTree.weightedEdges()
+	accepting := stateSet(dfaTree.AcceptStates)
+
+	counts := make([]*big.Int, 0, n+1)
+	// ways[state] is the number of strings of the current length leading to state
+	ways := map[string]*big.Int{dfaTree.StartState.StateName: big.NewInt(1)}
+	for length := 0; length <= n; length++ {
+		count := new(big.Int)
+		for state, number := range ways {
+			if accepting[state] {
+				count.Add(count, number)
+			}
+		}
+		counts = append(counts, count)
+
+		next := make(map[string]*big.Int)
+		for state, number := range ways {
+			for _, edge := range edges[state] {
+				if next[edge.target] == nil {
+					next[edge.target] = new(big.Int)
+				}
+				next[edge.target].Add(next[edge.target], new(big.Int).Mul(number, big.NewInt(edge.weight)))
+			}
+		}
+		ways = next
+	}
+	return counts
+}
+
+// GrowthRate returns the dominant eigenvalue of the transition matrix of the
+// useful states of the DFA, the number of accepted strings of length n grows
+// like GrowthRate()^n. It is 0 for a finite language. The result is an
+// approximation computed by at most powerIterations steps of the power
+// iteration, which stops early once it changes by less than 1e-12 relative.
+func (dfaTree *DFA) GrowthRate() float64 {
+	useless := dfaTree.Analyze().Useless()
+	component := dfaTree.graph().Components(dfaTree.States, useless)
+	members := make(map[int][]string)
+	for _, state := range dfaTree.States {
+		if !useless[state] {
+			members[component[state]] = append(members[component[state]], state)
+		}
+	}
+
+	// the dominant eigenvalue of the matrix is the largest one of the blocks
+	// of its strongly connected components
+	edges := dfaTree.weightedEdges()
+	rate := 0.0
+	for _, states := range members {
+		within := func(state string) bool { return !useless[state] && component[state] == component[states[0]] }
+		rate = math.Max(rate, dominantEigenvalue(states, edges, within))
+	}
+	return rate
+}
+
+// dominantEigenvalue runs the power iteration on A+I for the block A of the
+// transition matrix between the states of a strongly connected component.
+// Unlike A, A+I is never periodic so the iteration converges, and its dominant
+// eigenvalue is the one of A plus 1.
+func dominantEigenvalue(states []string, edges map[string][]weightedEdge, within func(string) bool) float64 {
+	vector := make(map[string]float64, len(states))
+	for _, state := range states {
+		vector[state] = 1 / float64(len(states))
+	}
+	rate := 0.0
+	for iteration := 0; iteration < powerIterations; iteration++ {
+		next := make(map[string]float64, len(states))
+		for _, state := range states {
+			next[state] += vector[state]
+			for _, edge := range edges[state] {
+				if within(edge.target) {
+					next[edge.target] += vector[state] * float64(edge.weight)
+				}
+			}
+		}
+		norm := 0.0
+		for _, state := range states {
+			norm += next[state]
+		}
+		for _, state := range states {
+			next[state] /= norm
+		}
+		vector = next
+		if math.Abs(norm-1-rate) <= 1e-12*norm {
+			return norm - 1
+		}
+		rate = norm - 1
+	}
+	return rate
+}
+
+// weightedEdges merges the transitions of every state by target, weighted by
+// the number of symbols leading there
+func (dfaTree *DFA) weightedEdges() map[string][]weightedEdge {
+	alphabet := dfaTree.alphabet()
+	edges := make(map[string][]weightedEdge, len(dfaTree.States))
+	for _, state := range dfaTree.States {
+		index := make(map[string]int)
+		for _, class := range alphabet {
+			target, exists := dfaTree.next(state, class.Min())
+			if !exists {
+				continue
+			}
+			if i, seen := index[target]; seen {
+				edges[state][i].weight += class.Size()
+				continue
+			}
+			index[target] = len(edges[state])
+			edges[state] = append(edges[state], weightedEdge{target: target, weight: class.Size()})
+		}
+	}
+	return edges
+}
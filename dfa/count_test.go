@@ -0,0 +1,83 @@
+package dfa
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+)
+
+func TestCountByLength(t *testing.T) {
+	ab := []rune("ab")
+	digits := NewSymbolic([]string{"s", "t"}, nil, []charclass.Class{charclass.Digit}, map[string]map[rune]string{},
+		map[string][]ClassTransition{
+			"s": {{Class: charclass.Digit, Target: "t"}},
+			"t": {{Class: charclass.Digit, Target: "t"}},
+		}, "s", []string{"t"})
+
+	tests := []struct {
+		name   string
+		dfa    *DFA
+		counts []int64
+		rate   float64
+	}{
+		{
+			name:   "ends in b",
+			dfa:    New([]string{"s", "t"}, ab, map[string]map[rune]string{"s": {'a': "s", 'b': "t"}, "t": {'a': "s", 'b': "t"}}, "s", []string{"t"}),
+			counts: []int64{0, 1, 2, 4, 8, 16},
+			rate:   2,
+		},
+		{
+			name:   "no aa",
+			dfa:    New([]string{"s", "t", "u"}, ab, map[string]map[rune]string{"s": {'a': "t", 'b': "s"}, "t": {'a': "u", 'b': "s"}, "u": {'a': "u", 'b': "u"}}, "s", []string{"s", "t"}),
+			counts: []int64{1, 2, 3, 5, 8, 13},
+			rate:   (1 + math.Sqrt(5)) / 2,
+		},
+		{
+			name:   "a* then b*",
+			dfa:    New([]string{"s", "t"}, ab, map[string]map[rune]string{"s": {'a': "s", 'b': "t"}, "t": {'b': "t"}}, "s", []string{"s", "t"}),
+			counts: []int64{1, 2, 3, 4, 5, 6},
+			rate:   1,
+		},
+		{
+			name:   "only ab",
+			dfa:    New([]string{"s", "t", "u"}, ab, map[string]map[rune]string{"s": {'a': "t"}, "t": {'b': "u"}}, "s", []string{"u"}),
+			counts: []int64{0, 0, 1, 0, 0, 0},
+			rate:   0,
+		},
+		{
+			name:   "digits",
+			dfa:    digits,
+			counts: []int64{0, 10, 100, 1000, 10000, 100000},
+			rate:   10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counts := test.dfa.CountByLength(len(test.counts) - 1)
+			if len(counts) != len(test.counts) {
+				t.Fatalf("CountByLength() returned %d counts; want %d", len(counts), len(test.counts))
+			}
+			for n, count := range counts {
+				if count.Int64() != test.counts[n] {
+					t.Errorf("CountByLength()[%d] = %v; want %d", n, count, test.counts[n])
+				}
+			}
+			if rate := test.dfa.GrowthRate(); math.Abs(rate-test.rate) > 1e-6 {
+				t.Errorf("GrowthRate() = %v; want %v", rate, test.rate)
+			}
+		})
+	}
+
+	// the counts do not fit into an int64 for long enough strings
+	if count := digits.CountByLength(30)[30]; count.String() != "1"+strings.Repeat("0", 30) {
+		t.Errorf("CountByLength(30)[30] = %v; want 10^30", count)
+	}
+	for _, n := range []int{-1, -2} {
+		if counts := digits.CountByLength(n); len(counts) != 0 {
+			t.Errorf("CountByLength(%d) = %v; want no counts", n, counts)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package dfa
+
+import (
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// IsEmpty reports whether the DFA accepts no string at all. Otherwise it also
+// returns the shortest accepted string as a witness.
+func (dfaTree *DFA) IsEmpty() (bool, []rune) {
+	word, found := dfaTree.ShortestAccepted("")
+	return !found, word
+}
+
+// IsFinite reports whether the DFA accepts finitely many strings. Otherwise it
+// also returns a loop that can be pumped to get infinitely many accepted
+// strings.
+func (dfaTree *DFA) IsFinite() (bool, *utils.Loop) {
+	loop := dfaTree.graph().FindLoop(dfaTree.States, dfaTree.StartState.StateName, dfaTree.AcceptStates)
+	return loop == nil, loop
+}
+
+// IsUniversal reports whether the DFA accepts every string over its alphabet.
+// Otherwise it also returns the shortest rejected string as a witness.
+func (dfaTree *DFA) IsUniversal() (bool, []rune) {
+	word, found := dfaTree.ShortestRejected("")
+	return !found, word
+}
+
+// graph returns the transition graph of the DFA with one edge per class of the
+// alphabet, labelled by the smallest rune of the class
+func (dfaTree *DFA) graph() utils.Graph {
+	alphabet := dfaTree.alphabet()
+	graph := make(utils.Graph, len(dfaTree.States))
+	for _, state := range dfaTree.States {
+		for _, class := range alphabet {
+			if target, exists := dfaTree.next(state, class.Min()); exists {
+				graph[state] = append(graph[state], utils.Edge{Target: target, Symbol: class.Min()})
+			}
+		}
+	}
+	return graph
+}
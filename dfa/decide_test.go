@@ -0,0 +1,88 @@
+package dfa
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecide(t *testing.T) {
+	ab := []rune("ab")
+	tests := []struct {
+		name      string
+		dfa       *DFA
+		empty     bool
+		accepted  string
+		finite    bool
+		universal bool
+		rejected  string
+	}{
+		{
+			name:     "nothing",
+			dfa:      New([]string{"s"}, ab, map[string]map[rune]string{"s": {'a': "s", 'b': "s"}}, "s", nil),
+			empty:    true,
+			finite:   true,
+			rejected: "",
+		},
+		{
+			name:     "only ab",
+			dfa:      New([]string{"s", "t", "u"}, ab, map[string]map[rune]string{"s": {'a': "t"}, "t": {'b': "u"}}, "s", []string{"u"}),
+			accepted: "ab",
+			finite:   true,
+			rejected: "",
+		},
+		{
+			name:     "a then b*",
+			dfa:      New([]string{"s", "t"}, ab, map[string]map[rune]string{"s": {'a': "t"}, "t": {'b': "t"}}, "s", []string{"t"}),
+			accepted: "a",
+			rejected: "",
+		},
+		{
+			name:     "no aa",
+			dfa:      New([]string{"s", "t", "u"}, ab, map[string]map[rune]string{"s": {'a': "t", 'b': "s"}, "t": {'a': "u", 'b': "s"}, "u": {'a': "u", 'b': "u"}}, "s", []string{"s", "t"}),
+			accepted: "",
+			rejected: "aa",
+		},
+		{
+			name:      "everything",
+			dfa:       New([]string{"s"}, ab, map[string]map[rune]string{"s": {'a': "s", 'b': "s"}}, "s", []string{"s"}),
+			accepted:  "",
+			universal: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			empty, accepted := test.dfa.IsEmpty()
+			if empty != test.empty || !empty && string(accepted) != test.accepted {
+				t.Errorf("IsEmpty() = %v, %q; want %v, %q", empty, string(accepted), test.empty, test.accepted)
+			}
+			if !empty && !test.dfa.ValidateString(accepted) {
+				t.Errorf("IsEmpty() witness %q is rejected", string(accepted))
+			}
+
+			finite, loop := test.dfa.IsFinite()
+			if finite != test.finite {
+				t.Errorf("IsFinite() = %v, %+v; want %v", finite, loop, test.finite)
+			}
+			if !finite {
+				if len(loop.Cycle) == 0 {
+					t.Errorf("IsFinite() loop %+v has an empty cycle", loop)
+				}
+				for k := 0; k < 4; k++ {
+					word := string(loop.Prefix) + strings.Repeat(string(loop.Cycle), k) + string(loop.Suffix)
+					if !test.dfa.ValidateString([]rune(word)) {
+						t.Errorf("IsFinite() loop %+v pumped %d times gives rejected %q", loop, k, word)
+					}
+				}
+			}
+
+			universal, rejected := test.dfa.IsUniversal()
+			if universal != test.universal || !universal && string(rejected) != test.rejected {
+				t.Errorf("IsUniversal() = %v, %q; want %v, %q", universal, string(rejected), test.universal, test.rejected)
+			}
+			if !universal && test.dfa.ValidateString(rejected) {
+				t.Errorf("IsUniversal() witness %q is accepted", string(rejected))
+			}
+		})
+	}
+}
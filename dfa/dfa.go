@@ -2,7 +2,9 @@ package dfa
 
 import (
 	"fmt"
+	"sort"
 	
+	"github.com/dekuu5/FiniteStateMachine/charclass"
 	"github.com/dekuu5/FiniteStateMachine/utils"
 )
 
@@ -11,6 +13,7 @@ import (
 type StateNode struct {
     StateName   string
     Transitions map[rune]*StateNode
+    ClassTransitions []ClassEdge
     IsAccepting bool               
 }
 
@@ -22,11 +25,14 @@ type DFA struct {
     Transitions  map[string]map[rune]string
 	StartState *StateNode 
     AcceptStates []string
+    Classes      []charclass.Class // the character classes of the alphabet, next to Symbols
+    ClassTransitions map[string][]ClassTransition
+    Tokens       *utils.Tokens // names the symbols when the alphabet is made of tokens, nil for runes
 }
 
-func constructNodes(jsonInput utils.FiniteAutomata) *StateNode {
+func constructNodes(states []string, transitions map[string]map[rune]string, classTransitions map[string][]ClassTransition, startState string, acceptStates []string) *StateNode {
     nodes := make(map[string]*StateNode)
-    for _, state := range jsonInput.States {
+    for _, state := range states {
         nodes[state] = &StateNode{
             StateName:   state,
             Transitions: make(map[rune]*StateNode),
@@ -34,54 +40,85 @@ func constructNodes(jsonInput utils.FiniteAutomata) *StateNode {
         }
     }
 
-    for _, acceptState := range jsonInput.AcceptStates {
+    for _, acceptState := range acceptStates {
         if node, exists := nodes[acceptState]; exists {
             node.IsAccepting = true
         }
     }
 
-    for state, transition := range jsonInput.Transitions {
+    for state, transition := range transitions {
         for symbol, targetState := range transition {
-            if len(symbol) == 1 {
-                nodes[state].Transitions[rune(symbol[0])] = nodes[targetState]
-            }
+            nodes[state].Transitions[symbol] = nodes[targetState]
         }
     }
 
-    return nodes[jsonInput.StartState]
+    for state, edges := range classTransitions {
+        for _, edge := range edges {
+            nodes[state].ClassTransitions = append(nodes[state].ClassTransitions, ClassEdge{Class: edge.Class, Target: nodes[edge.Target]})
+        }
+    }
+
+    return nodes[startState]
 
 }
+
+// New builds a DFA directly from its rune-keyed transition table. It is used
+// by Constructor and by the algorithms that generate new automata.
+func New(states []string, symbols []rune, transitions map[string]map[rune]string, startState string, acceptStates []string) *DFA {
+    return NewSymbolic(states, symbols, nil, transitions, nil, startState, acceptStates)
+}
+
+// NewSymbolic is New for DFAs whose alphabet and transitions also have
+// character classes.
+func NewSymbolic(states []string, symbols []rune, classes []charclass.Class, transitions map[string]map[rune]string, classTransitions map[string][]ClassTransition, startState string, acceptStates []string) *DFA {
+    return &DFA{
+        States:           states,
+        Symbols:          symbols,
+        Transitions:      transitions,
+        StartState:       constructNodes(states, transitions, classTransitions, startState, acceptStates),
+        AcceptStates:     acceptStates,
+        Classes:          classes,
+        ClassTransitions: classTransitions,
+    }
+}
+
 func Constructor(jsonInput utils.FiniteAutomata) *DFA {
     transitions := make(map[string]map[rune]string)
+    classTransitions := make(map[string][]ClassTransition)
+    tokens := jsonInput.TokenTable()
     for state , transition := range jsonInput.Transitions {
         t := make(map[rune]string)
         for k, m := range transition {
-        if len(k) == 1 {
-                t[rune(k[0])] = m
+        class, err := jsonInput.ParseLabel(k, tokens)
+        if err != nil {
+                fmt.Printf("Skipping key '%s': %v\n", k, err)
+        } else if r, ok := class.Rune(); ok {
+                t[r] = m
             } else {
-                fmt.Printf("Skipping key '%s' because it's not a single character\n", k)
+                classTransitions[state] = append(classTransitions[state], ClassTransition{Class: class, Target: m})
         }
         transitions[state] = t
     }   
+        // keep the class transitions in a stable order
+        edges := classTransitions[state]
+        sort.Slice(edges, func(i, j int) bool { return edges[i].Class.Min() < edges[j].Class.Min() })
     }
     
     symbols := make([]rune, 0)
+    classes := make([]charclass.Class, 0)
     for _,c := range jsonInput.Symbols{
-        if len(c) == 1 {
-                symbols = append(symbols, rune(c[0]))
+        class, err := jsonInput.ParseLabel(c, tokens)
+        if err != nil {
+                fmt.Printf("Skipping key '%s': %v\n", c, err)
+        } else if r, ok := class.Rune(); ok {
+                symbols = append(symbols, r)
             } else {
-                fmt.Printf("Skipping key '%s' because it's not a single character\n", c)
+                classes = append(classes, class)
         }
     }
-    dfa := &DFA{
-        States: jsonInput.States,
-        Symbols: symbols,
-        Transitions: transitions,
-        StartState: constructNodes(jsonInput),
-        AcceptStates: jsonInput.AcceptStates,
-    }
-    
 
-    return dfa
+    dfaTree := NewSymbolic(jsonInput.States, symbols, classes, transitions, classTransitions, jsonInput.StartState, jsonInput.AcceptStates)
+    dfaTree.Tokens = tokens
+    return dfaTree
     
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+package dfa
+
+import (
+	"io"
+	"sort"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// WriteDot writes the DFA as a Graphviz digraph. Accepting states are drawn
+// as double circles, the start state has an incoming arrow from an invisible
+// node and all the transitions between two states share one edge labelled
+// with their symbols in rune order followed by their classes.
+func (dfaTree *DFA) WriteDot(w io.Writer) error {
+	graph := utils.NewDotGraph("DFA", dfaTree.States, dfaTree.StartState.StateName, dfaTree.AcceptStates)
+	for _, state := range dfaTree.States {
+		row := dfaTree.Transitions[state]
+		symbols := make([]rune, 0, len(row))
+		for symbol := range row {
+			symbols = append(symbols, symbol)
+		}
+		sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+		for _, symbol := range symbols {
+			graph.AddLabel(state, row[symbol], utils.DotSymbol(dfaTree.Tokens, symbol))
+		}
+		for _, edge := range dfaTree.ClassTransitions[state] {
+			graph.AddLabel(state, edge.Target, edge.Class.String())
+		}
+	}
+	return graph.Write(w)
+}
@@ -0,0 +1,134 @@
+package dfa
+
+import (
+	"sort"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// Enumerator yields the strings accepted by a DFA in shortlex order: shorter
+// strings first, strings of the same length in the order of their symbols.
+// A character class is represented by its smallest rune.
+type Enumerator struct {
+	graph     utils.Graph
+	accepting map[string]bool
+	start     string
+	maxLength int // negative for no limit
+	maxCount  int // negative for no limit
+	bound     int // the longest accepted string for a finite language, otherwise negative
+
+	// finish[r] holds the states that reach an accepting state in exactly r steps
+	finish []map[string]bool
+	length int
+	stack  []enumerationFrame
+	word   []rune
+	count  int
+	begun  bool
+}
+
+// enumerationFrame is a state on the path of the current string together with
+// the index of its next edge to try
+type enumerationFrame struct {
+	state string
+	edge  int
+}
+
+// Enumerate returns an iterator over the accepted strings of the DFA in
+// shortlex order, stopping after strings of maxLength symbols or after
+// maxCount strings. A negative limit means no limit, the iterator of an
+// infinite language without limits never ends.
+func (dfaTree *DFA) Enumerate(maxLength, maxCount int) *Enumerator {
+	graph := dfaTree.graph()
+	for _, edges := range graph {
+		sort.SliceStable(edges, func(i, j int) bool { return edges[i].Symbol < edges[j].Symbol })
+	}
+	bound := -1
+	if finite, _ := dfaTree.IsFinite(); finite {
+		bound = len(dfaTree.States) - 1 // an accepted path cannot repeat a state
+	}
+	return &Enumerator{
+		graph:     graph,
+		accepting: stateSet(dfaTree.AcceptStates),
+		start:     dfaTree.StartState.StateName,
+		maxLength: maxLength,
+		maxCount:  maxCount,
+		bound:     bound,
+		finish:    []map[string]bool{stateSet(dfaTree.AcceptStates)},
+	}
+}
+
+// Next returns the next accepted string, ok is false once there are no more
+func (e *Enumerator) Next() (word []rune, ok bool) {
+	for {
+		if e.maxCount >= 0 && e.count >= e.maxCount {
+			return nil, false
+		}
+		if len(e.stack) == 0 {
+			// every string of the current length is done, go on with the next one
+			if e.begun {
+				e.length++
+			}
+			e.begun = true
+			if e.maxLength >= 0 && e.length > e.maxLength || e.bound >= 0 && e.length > e.bound {
+				return nil, false
+			}
+			if !e.finishes(e.start, e.length) {
+				continue
+			}
+			e.stack = append(e.stack, enumerationFrame{state: e.start})
+			e.word = e.word[:0]
+		}
+
+		top := &e.stack[len(e.stack)-1]
+		depth := len(e.stack) - 1
+		if depth == e.length { // the pruning guarantees an accepting state
+			word := append([]rune{}, e.word...)
+			e.pop()
+			e.count++
+			return word, true
+		}
+
+		edges := e.graph[top.state]
+		pushed := false
+		for top.edge < len(edges) {
+			edge := edges[top.edge]
+			top.edge++
+			if e.finishes(edge.Target, e.length-depth-1) {
+				e.stack = append(e.stack, enumerationFrame{state: edge.Target})
+				e.word = append(e.word, edge.Symbol)
+				pushed = true
+				break
+			}
+		}
+		if !pushed {
+			e.pop()
+		}
+	}
+}
+
+// pop removes the last state of the path and the symbol leading to it
+func (e *Enumerator) pop() {
+	e.stack = e.stack[:len(e.stack)-1]
+	if len(e.word) > 0 {
+		e.word = e.word[:len(e.word)-1]
+	}
+}
+
+// finishes reports whether the state reaches an accepting state in exactly
+// steps steps, extending the table as needed
+func (e *Enumerator) finishes(state string, steps int) bool {
+	for len(e.finish) <= steps {
+		previous := e.finish[len(e.finish)-1]
+		current := make(map[string]bool)
+		for from, edges := range e.graph {
+			for _, edge := range edges {
+				if previous[edge.Target] {
+					current[from] = true
+					break
+				}
+			}
+		}
+		e.finish = append(e.finish, current)
+	}
+	return e.finish[steps][state]
+}
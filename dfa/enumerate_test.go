@@ -0,0 +1,53 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+)
+
+// enumerateAll collects the strings yielded by the enumerator
+func enumerateAll(e *Enumerator) []string {
+	words := make([]string, 0)
+	for word, ok := e.Next(); ok; word, ok = e.Next() {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func TestEnumerate(t *testing.T) {
+	// strings over a and b ending in b, with a trap state that is never entered
+	endsInB := New([]string{"s", "t", "trap"}, []rune("ba"),
+		map[string]map[rune]string{
+			"s":    {'a': "s", 'b': "t"},
+			"t":    {'a': "s", 'b': "t"},
+			"trap": {'a': "trap", 'b': "trap"},
+		}, "s", []string{"t"})
+	// a or ab, c is only read in the dead part of the DFA
+	finite := New([]string{"s", "t", "u", "dead"}, []rune("abc"),
+		map[string]map[rune]string{
+			"s": {'a': "t", 'c': "dead"},
+			"t": {'b': "u"},
+		}, "s", []string{"t", "u"})
+
+	tests := []struct {
+		name      string
+		dfa       *DFA
+		maxLength int
+		maxCount  int
+		want      []string
+	}{
+		{"count", endsInB, -1, 7, []string{"b", "ab", "bb", "aab", "abb", "bab", "bbb"}},
+		{"length", endsInB, 2, -1, []string{"b", "ab", "bb"}},
+		{"both", endsInB, 2, 2, []string{"b", "ab"}},
+		{"finite", finite, -1, -1, []string{"a", "ab"}},
+		{"nothing", finite, 0, -1, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := enumerateAll(test.dfa.Enumerate(test.maxLength, test.maxCount))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Enumerate(%d, %d) = %q; want %q", test.maxLength, test.maxCount, got, test.want)
+			}
+		})
+	}
+}
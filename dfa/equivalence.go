@@ -0,0 +1,92 @@
+package dfa
+
+import "sort"
+
+// Automaton is anything that can be turned into a DFA. Both *DFA and the
+// NFA of the nfa package implement it.
+type Automaton interface {
+	ToDFA() *DFA
+}
+
+// ToDFA returns the DFA itself so a DFA can be used as an Automaton.
+func (dfaTree *DFA) ToDFA() *DFA {
+	return dfaTree
+}
+
+// Equivalent decides whether the two automata accept the same language.
+// When they differ it also returns the shortest string accepted by exactly
+// one of them, choosing the first one in symbol order among strings of that
+// length. Missing transitions and symbols outside an automaton's alphabet
+// lead to rejection. For automata over tokens the counterexample is in the
+// runes of the first DFA returned by ShareTokens.
+func Equivalent(a, b Automaton) (bool, []rune) {
+	left, right := shareTokens(a.ToDFA(), b.ToDFA())
+	alphabet := sharedAlphabet(left, right)
+
+	type pair struct {
+		left, right *StateNode // nil is the implicit dead state
+	}
+	type visit struct {
+		parent int
+		symbol rune
+	}
+
+	start := pair{left.StartState, right.StartState}
+	queue := []pair{start}
+	visits := []visit{{parent: -1}}
+	seen := map[pair]bool{start: true}
+
+	for i := 0; i < len(queue); i++ {
+		current := queue[i]
+		if isAccepting(current.left) != isAccepting(current.right) {
+			// walk back to the start pair to rebuild the counterexample
+			counterexample := make([]rune, 0)
+			for j := i; visits[j].parent != -1; j = visits[j].parent {
+				counterexample = append(counterexample, visits[j].symbol)
+			}
+			for l, r := 0, len(counterexample)-1; l < r; l, r = l+1, r-1 {
+				counterexample[l], counterexample[r] = counterexample[r], counterexample[l]
+			}
+			return false, counterexample
+		}
+		for _, class := range alphabet {
+			symbol := class.Min() // the smallest rune stands for the whole class
+			next := pair{step(current.left, symbol), step(current.right, symbol)}
+			if next.left == nil && next.right == nil {
+				continue // both automata reject every continuation
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+				visits = append(visits, visit{parent: i, symbol: symbol})
+			}
+		}
+	}
+	return true, nil
+}
+
+// step follows the transition of the node on the symbol, nil is the dead state
+func step(node *StateNode, symbol rune) *StateNode {
+	if node == nil {
+		return nil
+	}
+	return node.Next(symbol)
+}
+
+func isAccepting(node *StateNode) bool {
+	return node != nil && node.IsAccepting
+}
+
+// unionSymbols returns the sorted union of both alphabets
+func unionSymbols(a, b []rune) []rune {
+	seen := make(map[rune]bool, len(a)+len(b))
+	symbols := make([]rune, 0, len(a)+len(b))
+	for _, symbol := range append(append([]rune{}, a...), b...) {
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+	return symbols
+}
@@ -0,0 +1,64 @@
+package dfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestEquivalent(t *testing.T) {
+	// even number of a's
+	evenA := Constructor(utils.FiniteAutomata{
+		States:       []string{"e", "o"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "e",
+		AcceptStates: []string{"e"},
+		Transitions: map[string]map[string]string{
+			"e": {"a": "o", "b": "e"},
+			"o": {"a": "e", "b": "o"},
+		},
+	})
+	// the same language with redundant states
+	evenARedundant := Constructor(utils.FiniteAutomata{
+		States:       []string{"p0", "p1", "p2", "p3"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "p0",
+		AcceptStates: []string{"p0", "p2"},
+		Transitions: map[string]map[string]string{
+			"p0": {"a": "p1", "b": "p2"},
+			"p1": {"a": "p2", "b": "p3"},
+			"p2": {"a": "p3", "b": "p0"},
+			"p3": {"a": "p0", "b": "p1"},
+		},
+	})
+	// even number of a's, but a single b is rejected
+	partial := Constructor(utils.FiniteAutomata{
+		States:       []string{"e", "o", "eb"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "e",
+		AcceptStates: []string{"e"},
+		Transitions: map[string]map[string]string{
+			"e":  {"a": "o", "b": "eb"},
+			"o":  {"a": "e", "b": "o"},
+			"eb": {"a": "o", "b": "e"},
+		},
+	})
+
+	testCases := []struct {
+		a, b           *DFA
+		equivalent     bool
+		counterexample string
+	}{
+		{a: evenA, b: evenA, equivalent: true},
+		{a: evenA, b: evenARedundant, equivalent: true},
+		{a: evenA, b: partial, equivalent: false, counterexample: "b"},
+		{a: partial, b: evenA, equivalent: false, counterexample: "b"},
+	}
+
+	for _, tc := range testCases {
+		equivalent, counterexample := Equivalent(tc.a, tc.b)
+		if equivalent != tc.equivalent || string(counterexample) != tc.counterexample {
+			t.Errorf("Equivalent(%v, %v) = %v, %q; want %v, %q", tc.a.States, tc.b.States, equivalent, string(counterexample), tc.equivalent, tc.counterexample)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package dfa
+
+// Matcher advances an automaton one symbol at a time, for inputs whose symbols
+// arrive one after another. The matchers of DFAs and NFAs both implement it.
+type Matcher interface {
+	// Step reads one symbol and reports whether an accepting state can still be reached
+	Step(symbol rune) bool
+	// Feed reads the symbols in order like Step, stopping once the matcher is dead
+	Feed(symbols []rune) bool
+	// Accepting reports whether the symbols read so far are accepted
+	Accepting() bool
+	// Dead reports whether no continuation of the symbols read so far can be accepted
+	Dead() bool
+	// Reset forgets the symbols read and goes back to the start state
+	Reset()
+}
+
+// stateMatcher is the Matcher of a DFA, walking the node graph
+type stateMatcher struct {
+	start   *StateNode
+	current *StateNode // nil once a symbol had no transition
+	dead    map[string]bool
+}
+
+// NewMatcher returns a Matcher in the start state of the DFA. The states that
+// cannot reach an accepting state are found once here, so a stream is known
+// to be dead as soon as it enters one of them.
+func (dfaTree *DFA) NewMatcher() Matcher {
+	return &stateMatcher{
+		start:   dfaTree.StartState,
+		current: dfaTree.StartState,
+		dead:    stateSet(dfaTree.Analyze().Dead),
+	}
+}
+
+func (m *stateMatcher) Step(symbol rune) bool {
+	if m.current != nil {
+		m.current = m.current.Next(symbol)
+	}
+	return !m.Dead()
+}
+
+func (m *stateMatcher) Feed(symbols []rune) bool {
+	for _, symbol := range symbols {
+		if !m.Step(symbol) {
+			return false // a dead matcher stays dead, the rest cannot change that
+		}
+	}
+	return !m.Dead()
+}
+
+func (m *stateMatcher) Accepting() bool {
+	return m.current != nil && m.current.IsAccepting
+}
+
+func (m *stateMatcher) Dead() bool {
+	return m.current == nil || m.dead[m.current.StateName]
+}
+
+func (m *stateMatcher) Reset() {
+	m.current = m.start
+}
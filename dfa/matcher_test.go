@@ -0,0 +1,41 @@
+package dfa
+
+import "testing"
+
+func TestMatcher(t *testing.T) {
+	// strings over a and b without bb, trap is entered on the second b in a row
+	dfaTree := New([]string{"s", "b", "trap"}, []rune("ab"),
+		map[string]map[rune]string{
+			"s":    {'a': "s", 'b': "b"},
+			"b":    {'a': "s", 'b': "trap"},
+			"trap": {'a': "trap", 'b': "trap"},
+		}, "s", []string{"s", "b"})
+
+	m := dfaTree.NewMatcher()
+	if !m.Accepting() || m.Dead() {
+		t.Fatalf("new matcher: Accepting() = %v, Dead() = %v; want true, false", m.Accepting(), m.Dead())
+	}
+	if !m.Feed([]rune("abab")) || !m.Accepting() {
+		t.Errorf("Feed(abab) left the matcher dead or rejecting")
+	}
+	if m.Step('b') {
+		t.Errorf("Step(b) after abab = true; want the matcher to be dead on bb")
+	}
+	if m.Step('a') || !m.Dead() || m.Accepting() {
+		t.Errorf("Step(a) after the matcher died brought it back")
+	}
+	m.Reset()
+	if m.Dead() || !m.Step('b') || !m.Accepting() {
+		t.Errorf("Reset() did not go back to the start state")
+	}
+
+	// a partial DFA dies on a missing transition
+	partial := New([]string{"s", "t"}, []rune("ab"), map[string]map[rune]string{"s": {'a': "t"}, "t": {'a': "t"}}, "s", []string{"t"})
+	m = partial.NewMatcher()
+	if m.Accepting() || m.Dead() {
+		t.Errorf("new matcher of a+: Accepting() = %v, Dead() = %v; want false, false", m.Accepting(), m.Dead())
+	}
+	if m.Feed([]rune("ab")) || !m.Dead() {
+		t.Errorf("Feed(ab) on a+ = true; want dead on the missing transition")
+	}
+}
@@ -0,0 +1,236 @@
+package dfa
+
+import "sort"
+
+// Minimize returns the minimal DFA accepting the same language together with
+// a mapping from the old state names to the new ones.
+//
+// Unreachable states are removed and equivalent states are merged using
+// Hopcroft's partition refinement. Every merged state is named after its
+// first member in the order of States. Missing transitions are treated as
+// moves into an implicit dead state; states equivalent to it are dropped,
+// so a partial DFA stays partial. Removed states have no entry in the mapping.
+// Character classes are split into disjoint pieces with charclass.Alphabet
+// and merged back per target in the result.
+func (dfaTree *DFA) Minimize() (*DFA, map[string]string) {
+	alphabet := dfaTree.alphabet()
+	reachable := dfaTree.reachableStates()
+
+	// index the reachable states and add the implicit dead state if any transition is missing
+	index := make(map[string]int, len(reachable))
+	for i, state := range reachable {
+		index[state] = i
+	}
+	sink := -1
+	delta := make([][]int, len(reachable))
+	for i, state := range reachable {
+		delta[i] = make([]int, len(alphabet))
+		for j, class := range alphabet {
+			target, exists := dfaTree.next(state, class.Min())
+			if next, known := index[target]; exists && known {
+				delta[i][j] = next
+				continue
+			}
+			if sink == -1 {
+				sink = len(reachable)
+			}
+			delta[i][j] = sink
+		}
+	}
+	n := len(reachable)
+	if sink != -1 {
+		n++
+		row := make([]int, len(alphabet))
+		for j := range row {
+			row[j] = sink
+		}
+		delta = append(delta, row)
+	}
+
+	accepting := make([]bool, n)
+	for _, state := range dfaTree.AcceptStates {
+		if i, exists := index[state]; exists {
+			accepting[i] = true
+		}
+	}
+
+	blockOf := hopcroft(delta, accepting, len(alphabet))
+
+	// name every block after its first member in the order of States and drop
+	// the block of the dead state
+	position := make(map[string]int, len(dfaTree.States))
+	for i, state := range dfaTree.States {
+		position[state] = i
+	}
+	ordered := append([]string{}, reachable...)
+	sort.SliceStable(ordered, func(i, j int) bool { return position[ordered[i]] < position[ordered[j]] })
+	names := make(map[int]string)
+	mapping := make(map[string]string, len(reachable))
+	states := make([]string, 0)
+	for _, state := range ordered {
+		block := blockOf[index[state]]
+		if sink != -1 && block == blockOf[sink] {
+			continue
+		}
+		if _, named := names[block]; !named {
+			names[block] = state
+			states = append(states, state)
+		}
+		mapping[state] = names[block]
+	}
+
+	transitions := make(map[string]map[rune]string, len(states))
+	acceptStates := make([]string, 0)
+	for _, state := range states {
+		i := index[state]
+		t := make(map[rune]string)
+		for j, class := range alphabet {
+			if name, alive := names[blockOf[delta[i][j]]]; alive {
+				t[class.Min()] = name
+			}
+		}
+		transitions[state] = t
+		if accepting[i] {
+			acceptStates = append(acceptStates, state)
+		}
+	}
+
+	start := dfaTree.StartState.StateName
+	if _, alive := mapping[start]; !alive {
+		// the language is empty, keep a single rejecting start state
+		states = []string{start}
+		transitions = map[string]map[rune]string{start: {}}
+		mapping = map[string]string{start: start}
+	}
+
+	minimal := NewFromAlphabet(states, alphabet, transitions, mapping[start], acceptStates)
+	minimal.Tokens = dfaTree.Tokens
+	return minimal, mapping
+}
+
+// reachableStates returns the states reachable from the start state in
+// breadth first order, following the symbols in the order of Symbols and
+// then the character classes.
+func (dfaTree *DFA) reachableStates() []string {
+	alphabet := dfaTree.alphabet()
+	start := dfaTree.StartState.StateName
+	seen := map[string]bool{start: true}
+	order := []string{start}
+	for i := 0; i < len(order); i++ {
+		for _, class := range alphabet {
+			next, exists := dfaTree.next(order[i], class.Min())
+			if exists && !seen[next] && stateExists(dfaTree.States, next) {
+				seen[next] = true
+				order = append(order, next)
+			}
+		}
+	}
+	return order
+}
+
+// hopcroft refines the partition {accepting, rejecting} of a complete
+// transition table until it is stable and returns the block of every state.
+func hopcroft(delta [][]int, accepting []bool, symbols int) []int {
+	n := len(delta)
+
+	// inverse[c][j] lists the states moving to j on symbol c
+	inverse := make([][][]int, symbols)
+	for c := range inverse {
+		inverse[c] = make([][]int, n)
+	}
+	for i, row := range delta {
+		for c, j := range row {
+			inverse[c][j] = append(inverse[c][j], i)
+		}
+	}
+
+	blockOf := make([]int, n)
+	blocks := make([][]int, 0, 2)
+	var accept, reject []int
+	for i := 0; i < n; i++ {
+		if accepting[i] {
+			accept = append(accept, i)
+		} else {
+			reject = append(reject, i)
+		}
+	}
+	for _, members := range [][]int{accept, reject} {
+		if len(members) == 0 {
+			continue
+		}
+		for _, i := range members {
+			blockOf[i] = len(blocks)
+		}
+		blocks = append(blocks, members)
+	}
+	if len(blocks) < 2 {
+		return blockOf
+	}
+
+	// only the smaller of the two initial blocks has to be used as a splitter
+	inWork := make([]bool, len(blocks))
+	work := []int{0}
+	if len(blocks[1]) < len(blocks[0]) {
+		work[0] = 1
+	}
+	inWork[work[0]] = true
+
+	for len(work) > 0 {
+		splitter := append([]int{}, blocks[work[0]]...)
+		inWork[work[0]] = false
+		work = work[1:]
+
+		for c := 0; c < symbols; c++ {
+			// group the predecessors of the splitter by their block
+			touched := make(map[int][]int)
+			order := make([]int, 0)
+			for _, j := range splitter {
+				for _, i := range inverse[c][j] {
+					block := blockOf[i]
+					if _, ok := touched[block]; !ok {
+						order = append(order, block)
+					}
+					touched[block] = append(touched[block], i)
+				}
+			}
+
+			for _, block := range order {
+				moved := touched[block]
+				if len(moved) == len(blocks[block]) {
+					continue
+				}
+				isMoved := make(map[int]bool, len(moved))
+				for _, i := range moved {
+					isMoved[i] = true
+				}
+				rest := make([]int, 0, len(blocks[block])-len(moved))
+				for _, i := range blocks[block] {
+					if !isMoved[i] {
+						rest = append(rest, i)
+					}
+				}
+
+				created := len(blocks)
+				blocks[block] = rest
+				blocks = append(blocks, moved)
+				inWork = append(inWork, false)
+				for _, i := range moved {
+					blockOf[i] = created
+				}
+
+				switch {
+				case inWork[block]:
+					work = append(work, created)
+					inWork[created] = true
+				case len(moved) < len(rest):
+					work = append(work, created)
+					inWork[created] = true
+				default:
+					work = append(work, block)
+					inWork[block] = true
+				}
+			}
+		}
+	}
+	return blockOf
+}
@@ -0,0 +1,99 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestMinimize(t *testing.T) {
+	// strings over {a,b} ending in b; q1/q3 and q0/q2 are equivalent and q4 is unreachable
+	dfaTree := Constructor(utils.FiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3", "q4"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q1", "q3"},
+		Transitions: map[string]map[string]string{
+			"q0": {"a": "q2", "b": "q1"},
+			"q1": {"a": "q0", "b": "q3"},
+			"q2": {"a": "q0", "b": "q3"},
+			"q3": {"a": "q2", "b": "q1"},
+			"q4": {"a": "q0", "b": "q1"},
+		},
+	})
+
+	minimal, mapping := dfaTree.Minimize()
+
+	if len(minimal.States) != 2 {
+		t.Fatalf("Minimize() has states %v; want 2 states", minimal.States)
+	}
+	expectedMapping := map[string]string{"q0": "q0", "q1": "q1", "q2": "q0", "q3": "q1"}
+	for old, expected := range expectedMapping {
+		if mapping[old] != expected {
+			t.Errorf("mapping[%s] = %q; want %q", old, mapping[old], expected)
+		}
+	}
+	if _, exists := mapping["q4"]; exists {
+		t.Errorf("unreachable state q4 is in the mapping")
+	}
+
+	for _, input := range []string{"", "a", "b", "ab", "ba", "abb", "bba", "babab"} {
+		if minimal.ValidateString([]rune(input)) != dfaTree.ValidateString([]rune(input)) {
+			t.Errorf("Minimize().ValidateString(%q) differs from the original DFA", input)
+		}
+	}
+}
+
+func TestMinimizePartial(t *testing.T) {
+	// "ab" or "abab...", the explicit trap q3 is equivalent to the missing transitions
+	dfaTree := Constructor(utils.FiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string]string{
+			"q0": {"a": "q1", "b": "q3"},
+			"q1": {"b": "q2"},
+			"q2": {"a": "q1"},
+			"q3": {"a": "q3", "b": "q3"},
+		},
+	})
+
+	minimal, mapping := dfaTree.Minimize()
+
+	if len(minimal.States) != 3 {
+		t.Errorf("Minimize() has states %v; want 3 states", minimal.States)
+	}
+	if _, exists := mapping["q3"]; exists {
+		t.Errorf("dead state q3 is in the mapping")
+	}
+	for _, input := range []string{"", "ab", "abab", "b", "aba", "abb"} {
+		if minimal.ValidateString([]rune(input)) != dfaTree.ValidateString([]rune(input)) {
+			t.Errorf("Minimize().ValidateString(%q) differs from the original DFA", input)
+		}
+	}
+}
+
+func TestMinimizeNames(t *testing.T) {
+	// q1 and q2 are equivalent, q2 is reached first but q1 comes first in States
+	dfaTree := Constructor(utils.FiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q1", "q2"},
+		Transitions: map[string]map[string]string{
+			"q0": {"a": "q2", "b": "q1"},
+			"q1": {"a": "q1", "b": "q1"},
+			"q2": {"a": "q2", "b": "q2"},
+		},
+	})
+
+	minimal, mapping := dfaTree.Minimize()
+	if !reflect.DeepEqual(minimal.States, []string{"q0", "q1"}) {
+		t.Errorf("Minimize() has states %v; want [q0 q1]", minimal.States)
+	}
+	if mapping["q2"] != "q1" {
+		t.Errorf("mapping[q2] = %q; want \"q1\"", mapping["q2"])
+	}
+}
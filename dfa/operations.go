@@ -0,0 +1,208 @@
+package dfa
+
+import (
+	"strings"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+// Complement returns a DFA accepting every string over Symbols and Classes
+// that this DFA rejects. The DFA is completed with a sink state first so that strings
+// running into a missing transition become accepted.
+func (dfaTree *DFA) Complement() *DFA {
+	complete := dfaTree.completed(dfaTree.alphabet())
+	accepting := stateSet(complete.AcceptStates)
+	acceptStates := make([]string, 0)
+	for _, state := range complete.States {
+		if !accepting[state] {
+			acceptStates = append(acceptStates, state)
+		}
+	}
+	complement := NewSymbolic(complete.States, complete.Symbols, complete.Classes, complete.Transitions, complete.ClassTransitions, complete.StartState.StateName, acceptStates)
+	complement.Tokens = dfaTree.Tokens
+	return complement
+}
+
+// Complete returns an equivalent DFA in which every state has a transition on
+// every symbol and class. The implicit dead state of a partial DFA becomes a
+// rejecting state named "sink", or "sink1", ... if that name is taken. No
+// state is added to a DFA that is already complete.
+func (dfaTree *DFA) Complete() *DFA {
+	return dfaTree.completed(dfaTree.alphabet())
+}
+
+// IsComplete reports whether every state has a transition on every symbol and
+// class, i.e. the DFA is not partial.
+func (dfaTree *DFA) IsComplete() bool {
+	alphabet := dfaTree.alphabet()
+	for _, state := range dfaTree.States {
+		for _, class := range alphabet {
+			if _, exists := dfaTree.next(state, class.Min()); !exists {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Intersection returns a DFA accepting the strings accepted by both DFAs.
+func (dfaTree *DFA) Intersection(other *DFA) *DFA {
+	return product(dfaTree, other, func(a, b bool) bool { return a && b })
+}
+
+// Union returns a DFA accepting the strings accepted by either DFA.
+func (dfaTree *DFA) Union(other *DFA) *DFA {
+	return product(dfaTree, other, func(a, b bool) bool { return a || b })
+}
+
+// Difference returns a DFA accepting the strings accepted by this DFA and
+// rejected by the other one.
+func (dfaTree *DFA) Difference(other *DFA) *DFA {
+	return product(dfaTree, other, func(a, b bool) bool { return a && !b })
+}
+
+// SymmetricDifference returns a DFA accepting the strings accepted by exactly
+// one of the DFAs.
+func (dfaTree *DFA) SymmetricDifference(other *DFA) *DFA {
+	return product(dfaTree, other, func(a, b bool) bool { return a != b })
+}
+
+// ToFiniteAutomata converts the DFA back to the JSON representation read by
+// utils.ReadJson.
+func (dfaTree *DFA) ToFiniteAutomata() utils.FiniteAutomata {
+	symbols := make([]string, 0, len(dfaTree.Symbols)+len(dfaTree.Classes))
+	for _, symbol := range dfaTree.Symbols {
+		symbols = append(symbols, dfaTree.Tokens.Symbol(symbol))
+	}
+	for _, class := range dfaTree.Classes {
+		symbols = append(symbols, class.String())
+	}
+	transitions := make(map[string]map[string]string, len(dfaTree.Transitions))
+	for state, transition := range dfaTree.Transitions {
+		t := make(map[string]string, len(transition))
+		for symbol, target := range transition {
+			t[dfaTree.Tokens.Symbol(symbol)] = target
+		}
+		transitions[state] = t
+	}
+	for state, edges := range dfaTree.ClassTransitions {
+		if len(edges) > 0 && transitions[state] == nil {
+			transitions[state] = make(map[string]string, len(edges))
+		}
+		for _, edge := range edges {
+			transitions[state][edge.Class.String()] = edge.Target
+		}
+	}
+	alphabet := ""
+	if dfaTree.HasTokenAlphabet() {
+		alphabet = utils.AlphabetTokens
+	}
+	return utils.FiniteAutomata{
+		States:       append([]string{}, dfaTree.States...),
+		Symbols:      symbols,
+		StartState:   dfaTree.StartState.StateName,
+		AcceptStates: append([]string{}, dfaTree.AcceptStates...),
+		Transitions:  transitions,
+		Alphabet:     alphabet,
+		Partial:      !dfaTree.IsComplete(),
+	}
+}
+
+// product builds the reachable part of the product of both DFAs over the
+// union of their alphabets, split into disjoint classes, and over the tokens of both. A product state accepts when accept returns true
+// for the acceptance of its two components.
+func product(a, b *DFA, accept func(a, b bool) bool) *DFA {
+	a, b = shareTokens(a, b)
+	alphabet := sharedAlphabet(a, b)
+	left, right := a.completed(alphabet), b.completed(alphabet)
+	leftAccepting, rightAccepting := stateSet(left.AcceptStates), stateSet(right.AcceptStates)
+
+	type pair struct{ left, right string }
+	name := func(p pair) string { return pairName(p.left, p.right) }
+
+	start := pair{left.StartState.StateName, right.StartState.StateName}
+	queue := []pair{start}
+	seen := map[pair]bool{start: true}
+	states := make([]string, 0)
+	acceptStates := make([]string, 0)
+	transitions := make(map[string]map[rune]string)
+
+	for i := 0; i < len(queue); i++ {
+		current := queue[i]
+		currentName := name(current)
+		states = append(states, currentName)
+		if accept(leftAccepting[current.left], rightAccepting[current.right]) {
+			acceptStates = append(acceptStates, currentName)
+		}
+		transitions[currentName] = make(map[rune]string, len(alphabet))
+		for _, class := range alphabet {
+			symbol := class.Min()
+			leftNext, _ := left.next(current.left, symbol)
+			rightNext, _ := right.next(current.right, symbol)
+			next := pair{leftNext, rightNext}
+			transitions[currentName][symbol] = name(next)
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	result := NewFromAlphabet(states, alphabet, transitions, name(start), acceptStates)
+	result.Tokens = a.Tokens
+	return result
+}
+
+// completed returns a copy of the DFA over the given alphabet of disjoint
+// classes in which every state has a transition on every class. Missing
+// transitions go to a new rejecting sink state, which is only added when needed.
+func (dfaTree *DFA) completed(alphabet []charclass.Class) *DFA {
+	sink := utils.FreshStateName("sink", dfaTree.States)
+	states := append([]string{}, dfaTree.States...)
+	transitions := make(map[string]map[rune]string, len(states)+1)
+	needsSink := false
+
+	for _, state := range states {
+		t := make(map[rune]string, len(alphabet))
+		for _, class := range alphabet {
+			symbol := class.Min()
+			if target, exists := dfaTree.next(state, symbol); exists {
+				t[symbol] = target
+			} else {
+				t[symbol] = sink
+				needsSink = true
+			}
+		}
+		transitions[state] = t
+	}
+
+	if needsSink {
+		states = append(states, sink)
+		transitions[sink] = make(map[rune]string, len(alphabet))
+		for _, class := range alphabet {
+			transitions[sink][class.Min()] = sink
+		}
+	}
+
+	complete := NewFromAlphabet(states, alphabet, transitions, dfaTree.StartState.StateName, append([]string{}, dfaTree.AcceptStates...))
+	complete.Tokens = dfaTree.Tokens
+	return complete
+}
+
+// pairName names the product state of two states "(left,right)", escaping
+// backslashes, commas and parentheses in the names so that different pairs
+// never share a name
+func pairName(left, right string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `,`, `\,`, `(`, `\(`, `)`, `\)`)
+	return "(" + escape.Replace(left) + "," + escape.Replace(right) + ")"
+}
+
+// stateSet returns the states as a set
+func stateSet(states []string) map[string]bool {
+	set := make(map[string]bool, len(states))
+	for _, state := range states {
+		set[state] = true
+	}
+	return set
+}
@@ -0,0 +1,171 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestBooleanOperations(t *testing.T) {
+	// odd number of a's
+	oddA := Constructor(utils.FiniteAutomata{
+		States:       []string{"e", "o"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "e",
+		AcceptStates: []string{"o"},
+		Transitions: map[string]map[string]string{
+			"e": {"a": "o", "b": "e"},
+			"o": {"a": "e", "b": "o"},
+		},
+	})
+	// exactly "ab", partial and over a larger alphabet
+	ab := Constructor(utils.FiniteAutomata{
+		States:       []string{"s0", "s1", "s2"},
+		Symbols:      []string{"a", "b", "c"},
+		StartState:   "s0",
+		AcceptStates: []string{"s2"},
+		Transitions: map[string]map[string]string{
+			"s0": {"a": "s1"},
+			"s1": {"b": "s2"},
+		},
+	})
+
+	testCases := []struct {
+		name     string
+		result   *DFA
+		expected func(a, b bool) bool
+	}{
+		{name: "Complement", result: ab.Complement(), expected: func(_, b bool) bool { return !b }},
+		{name: "Intersection", result: oddA.Intersection(ab), expected: func(a, b bool) bool { return a && b }},
+		{name: "Union", result: oddA.Union(ab), expected: func(a, b bool) bool { return a || b }},
+		{name: "Difference", result: oddA.Difference(ab), expected: func(a, b bool) bool { return a && !b }},
+		{name: "SymmetricDifference", result: oddA.SymmetricDifference(ab), expected: func(a, b bool) bool { return a != b }},
+	}
+
+	for _, tc := range testCases {
+		for _, input := range allStrings([]rune("abc"), 4) {
+			expected := tc.expected(oddA.ValidateString(input), ab.ValidateString(input))
+			if result := tc.result.ValidateString(input); result != expected {
+				t.Errorf("%s().ValidateString(%q) = %v; want %v", tc.name, string(input), result, expected)
+			}
+		}
+
+		// the result must survive a round trip through the JSON representation
+		automata := tc.result.ToFiniteAutomata()
+		if !ValidateDfa(automata) {
+			t.Errorf("%s().ToFiniteAutomata() is not a valid DFA", tc.name)
+		}
+		if equivalent, counterexample := Equivalent(tc.result, Constructor(automata)); !equivalent {
+			t.Errorf("%s() changed after a round trip, counterexample %q", tc.name, string(counterexample))
+		}
+	}
+}
+
+// allStrings returns every string over the symbols up to the given length
+func allStrings(symbols []rune, length int) [][]rune {
+	result := [][]rune{{}}
+	for i := 0; i < len(result); i++ {
+		if len(result[i]) == length {
+			continue
+		}
+		for _, symbol := range symbols {
+			result = append(result, append(append([]rune{}, result[i]...), symbol))
+		}
+	}
+	return result
+}
+
+func TestComplete(t *testing.T) {
+	// exactly "ab", with the state "sink" already taken
+	partial := Constructor(utils.FiniteAutomata{
+		States:       []string{"s0", "sink", "s2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "s0",
+		AcceptStates: []string{"s2"},
+		Transitions: map[string]map[string]string{
+			"s0":   {"a": "sink"},
+			"sink": {"b": "s2"},
+		},
+		Partial: true,
+	})
+	if partial.IsComplete() {
+		t.Fatalf("IsComplete() = true; want false")
+	}
+
+	complete := partial.Complete()
+	if !complete.IsComplete() {
+		t.Errorf("Complete().IsComplete() = false; want true")
+	}
+	if got := complete.States; !reflect.DeepEqual(got, []string{"s0", "sink", "s2", "sink1"}) {
+		t.Errorf("Complete().States = %v; want the dead state added as sink1", got)
+	}
+	if equivalent, counterexample := Equivalent(partial, complete); !equivalent {
+		t.Errorf("Complete() differs on %q", string(counterexample))
+	}
+	if again := complete.Complete(); len(again.States) != len(complete.States) {
+		t.Errorf("Complete().Complete().States = %v; want no new state", again.States)
+	}
+
+	// the JSON of a partial DFA says so and passes validation
+	automata := partial.ToFiniteAutomata()
+	if !automata.Partial || complete.ToFiniteAutomata().Partial {
+		t.Errorf("ToFiniteAutomata().Partial = %v, %v; want true, false", automata.Partial, complete.ToFiniteAutomata().Partial)
+	}
+	if report := ValidateDfaReport(automata); !report.Valid() {
+		t.Errorf("ValidateDfaReport(ToFiniteAutomata()) = %+v; want valid", report.Issues)
+	}
+}
+
+func TestEmptyLanguageRoundTrip(t *testing.T) {
+	universal := New([]string{"q"}, []rune("a"), map[string]map[rune]string{"q": {'a': "q"}}, "q", []string{"q"})
+	results := map[string]*DFA{
+		"complement":   universal.Complement(),
+		"intersection": universal.Intersection(universal.Complement()),
+		"difference":   universal.Difference(universal),
+	}
+	for name, result := range results {
+		automata := result.ToFiniteAutomata()
+		if len(automata.AcceptStates) != 0 {
+			t.Errorf("%s accepts in %v; want no accepting state", name, automata.AcceptStates)
+		}
+		report := ValidateDfaReport(automata)
+		if !report.Valid() {
+			t.Errorf("%s does not validate: %+v", name, report.Issues)
+			continue
+		}
+		if empty, accepted := Constructor(automata).IsEmpty(); !empty {
+			t.Errorf("%s reloaded accepts %q; want nothing", name, string(accepted))
+		}
+	}
+}
+
+func TestProductStateNames(t *testing.T) {
+	// the pairs (a, "b,c") and ("a,b", c) would both be named "(a,b,c)" without escaping
+	left := Constructor(utils.FiniteAutomata{
+		States:       []string{"a", "a,b"},
+		Symbols:      []string{"x"},
+		StartState:   "a",
+		AcceptStates: []string{"a,b"},
+		Transitions:  map[string]map[string]string{"a": {"x": "a,b"}, "a,b": {"x": "a,b"}},
+	})
+	right := Constructor(utils.FiniteAutomata{
+		States:       []string{"b,c", "c"},
+		Symbols:      []string{"x"},
+		StartState:   "b,c",
+		AcceptStates: []string{"c"},
+		Transitions:  map[string]map[string]string{"b,c": {"x": "c"}, "c": {"x": "c"}},
+	})
+
+	product := left.Intersection(right)
+	if !reflect.DeepEqual(product.States, []string{`(a,b\,c)`, `(a\,b,c)`}) {
+		t.Errorf("Intersection() has states %v; want [(a,b\\,c) (a\\,b,c)]", product.States)
+	}
+	reloaded := Constructor(product.ToFiniteAutomata())
+	for _, input := range []string{"", "x", "xx"} {
+		expected := input != ""
+		if result := reloaded.ValidateString([]rune(input)); result != expected {
+			t.Errorf("reloaded Intersection().ValidateString(%q) = %v; want %v", input, result, expected)
+		}
+	}
+}
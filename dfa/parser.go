@@ -3,7 +3,6 @@ package dfa
 
 import (
 	"container/list"
-	
 )
 
 
@@ -23,8 +22,8 @@ func (dfaTree *DFA)ValidateString( symbols []rune) bool {
         symbol := element.Value.(rune)
         queue.Remove(element)
 
-        nextNode, exists := currentNode.Transitions[symbol]
-        if !exists {
+        nextNode := currentNode.Next(symbol)
+        if nextNode == nil {
             return false
         }
         currentNode = nextNode
@@ -33,3 +32,18 @@ func (dfaTree *DFA)ValidateString( symbols []rune) bool {
     // Check if the current state is an accepting state
     return currentNode.IsAccepting
 }
+
+// ValidateTokens is ValidateString for DFAs over a token alphabet, a token
+// the DFA does not know is rejected
+func (dfaTree *DFA) ValidateTokens(tokens []string) bool {
+    symbols, known := dfaTree.Tokens.Runes(tokens)
+    if !known {
+        return false
+    }
+    return dfaTree.ValidateString(symbols)
+}
+
+// HasTokenAlphabet reports whether the symbols of the DFA are tokens
+func (dfaTree *DFA) HasTokenAlphabet() bool {
+    return dfaTree.Tokens != nil
+}
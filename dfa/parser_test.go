@@ -0,0 +1,141 @@
+package dfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestValidateTokens(t *testing.T) {
+	// a request may only follow a login, logout ends the session
+	automata := utils.FiniteAutomata{
+		Alphabet:     utils.AlphabetTokens,
+		States:       []string{"out", "in"},
+		Symbols:      []string{"LOGIN", "GET", "LOGOUT"},
+		StartState:   "out",
+		AcceptStates: []string{"out"},
+		Transitions: map[string]map[string]string{
+			"out": {"LOGIN": "in"},
+			"in":  {"GET": "in", "LOGOUT": "out"},
+		},
+	}
+	if report := ValidateDfaReport(automata); len(report.Issues) != 2 || !hasOnlyCode(report, utils.CodeIncompleteTransition) {
+		t.Errorf("ValidateDfaReport() = %+v; want only the incomplete transitions", report.Issues)
+	}
+
+	dfaTree := Constructor(automata)
+	if !dfaTree.HasTokenAlphabet() {
+		t.Errorf("HasTokenAlphabet() = false; want true")
+	}
+
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "", expected: true},
+		{input: "LOGIN GET GET LOGOUT", expected: true},
+		{input: `["LOGIN", "LOGOUT", "LOGIN", "LOGOUT"]`, expected: true},
+		{input: "LOGIN GET", expected: false},
+		{input: "GET", expected: false},
+		{input: "LOGIN PUT LOGOUT", expected: false},
+	}
+	for _, tc := range testCases {
+		tokens, err := utils.ParseTokens(tc.input)
+		if err != nil {
+			t.Fatalf("ParseTokens(%q) returned error %v", tc.input, err)
+		}
+		if result := dfaTree.ValidateTokens(tokens); result != tc.expected {
+			t.Errorf("ValidateTokens(%q) = %v; want %v", tc.input, result, tc.expected)
+		}
+	}
+
+	// the tokens survive a round trip through the JSON representation
+	roundTrip := dfaTree.ToFiniteAutomata()
+	if roundTrip.Alphabet != utils.AlphabetTokens || roundTrip.Transitions["in"]["LOGOUT"] != "out" {
+		t.Errorf("ToFiniteAutomata() = %+v; want the token alphabet back", roundTrip)
+	}
+}
+
+func hasOnlyCode(report *utils.Report, code string) bool {
+	for _, issue := range report.Issues {
+		if issue.Code != code {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPrivateUseRunes(t *testing.T) {
+	// characters of the private use planes are ordinary symbols of a runes alphabet
+	automata := utils.FiniteAutomata{
+		States:       []string{"q0", "q1"},
+		Symbols:      []string{"\U000F0000", "\U0010FFFD"},
+		StartState:   "q0",
+		AcceptStates: []string{"q1"},
+		Transitions: map[string]map[string]string{
+			"q0": {"\U000F0000": "q1"},
+			"q1": {"\U0010FFFD": "q1"},
+		},
+	}
+	dfaTree := Constructor(automata)
+	if dfaTree.HasTokenAlphabet() {
+		t.Errorf("HasTokenAlphabet() = true; want false")
+	}
+	if !dfaTree.ValidateString([]rune("\U000F0000\U0010FFFD")) {
+		t.Errorf("ValidateString() rejected a string over the private use runes")
+	}
+	roundTrip := dfaTree.ToFiniteAutomata()
+	if roundTrip.Alphabet != "" || roundTrip.Transitions["q0"]["\U000F0000"] != "q1" || roundTrip.Transitions["q1"]["\U0010FFFD"] != "q1" {
+		t.Errorf("ToFiniteAutomata() = %+v; want the private use runes back", roundTrip)
+	}
+}
+
+func TestCombineTokenAlphabets(t *testing.T) {
+	// both DFAs accept GET* but number their tokens in a different order
+	getOnly := Constructor(utils.FiniteAutomata{
+		Alphabet:     utils.AlphabetTokens,
+		States:       []string{"q0"},
+		Symbols:      []string{"GET", "POST"},
+		StartState:   "q0",
+		AcceptStates: []string{"q0"},
+		Transitions:  map[string]map[string]string{"q0": {"GET": "q0"}},
+	})
+	reordered := Constructor(utils.FiniteAutomata{
+		Alphabet:     utils.AlphabetTokens,
+		States:       []string{"q0"},
+		Symbols:      []string{"PUT", "POST", "GET"},
+		StartState:   "q0",
+		AcceptStates: []string{"q0"},
+		Transitions:  map[string]map[string]string{"q0": {"GET": "q0"}},
+	})
+	if getOnly.Tokens == reordered.Tokens {
+		t.Fatalf("Constructor() shared the token table of two DFAs")
+	}
+	if equivalent, counterexample := Equivalent(getOnly, reordered); !equivalent {
+		t.Errorf("Equivalent() = false, %v; want true", counterexample)
+	}
+
+	union := getOnly.Union(reordered.Complement())
+	if !union.HasTokenAlphabet() {
+		t.Fatalf("Union() lost the token alphabet")
+	}
+	for _, input := range [][]string{{"GET", "GET"}, {"PUT"}, {"POST", "GET"}} {
+		if !union.ValidateTokens(input) {
+			t.Errorf("Union().ValidateTokens(%v) = false; want true", input)
+		}
+	}
+	if union.ValidateTokens([]string{"DELETE"}) {
+		t.Errorf("Union().ValidateTokens([DELETE]) = true; want false")
+	}
+	if symbols := union.ToFiniteAutomata().Symbols; len(symbols) != 3 {
+		t.Errorf("Union().ToFiniteAutomata().Symbols = %v; want GET, POST and PUT", symbols)
+	}
+
+	left, right, err := ShareTokens(getOnly, reordered)
+	if err != nil {
+		t.Fatalf("ShareTokens() returned error %v", err)
+	}
+	if left.Tokens != right.Tokens || !right.ValidateTokens([]string{"GET"}) || right.ValidateTokens([]string{"PUT"}) {
+		t.Errorf("ShareTokens() = %+v, %+v; want both DFAs over one table", left, right)
+	}
+}
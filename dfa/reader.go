@@ -0,0 +1,83 @@
+package dfa
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// MatchOptions changes how MatchReader reads its input
+type MatchOptions struct {
+	// StopWhenDead stops reading as soon as no continuation of the input read
+	// so far can be accepted, instead of reading up to the end
+	StopWhenDead bool
+}
+
+// MatchResult is the outcome of MatchReader
+type MatchResult struct {
+	Accepted bool
+	Dead     bool  // the automaton reached a dead state
+	Bytes    int64 // the number of bytes consumed
+	Runes    int64 // the number of runes consumed
+}
+
+// InvalidUTF8Error reports input that is not valid UTF-8
+type InvalidUTF8Error struct {
+	Offset int64 // the index of the first invalid byte
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("invalid UTF-8 at byte %d", e.Offset)
+}
+
+// ErrTokenAlphabet is returned when matching text against an automaton whose
+// symbols are tokens
+var ErrTokenAlphabet = errors.New("an automaton over tokens cannot match text")
+
+// MatchReader feeds the runes of r to the matcher, decoding UTF-8 as it
+// reads, so the input never has to fit in memory. An io.RuneReader such as a
+// bufio.Reader is read directly, any other reader is buffered. The counts of
+// the result cover the input read before an error or before stopping at a
+// dead state.
+func MatchReader(m Matcher, r io.Reader, options MatchOptions) (MatchResult, error) {
+	runes, ok := r.(io.RuneReader)
+	if !ok {
+		runes = bufio.NewReaderSize(r, 64*1024)
+	}
+
+	var result MatchResult
+	for {
+		symbol, size, err := runes.ReadRune()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		if symbol == utf8.RuneError && size == 1 {
+			return result, &InvalidUTF8Error{Offset: result.Bytes}
+		}
+		result.Bytes += int64(size)
+		result.Runes++
+
+		if !result.Dead && !m.Step(symbol) {
+			result.Dead = true
+			if options.StopWhenDead {
+				return result, nil
+			}
+		}
+	}
+	result.Accepted = m.Accepting()
+	return result, nil
+}
+
+// MatchReader runs the DFA over the runes of r, see the MatchReader function.
+// A DFA over tokens cannot read text and returns ErrTokenAlphabet.
+func (dfaTree *DFA) MatchReader(r io.Reader, options MatchOptions) (MatchResult, error) {
+	if dfaTree.Tokens != nil {
+		return MatchResult{}, ErrTokenAlphabet
+	}
+	return MatchReader(dfaTree.NewMatcher(), r, options)
+}
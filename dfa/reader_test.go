@@ -0,0 +1,58 @@
+package dfa
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestMatchReader(t *testing.T) {
+	// any number of letters, a digit makes the input dead
+	letters := NewSymbolic([]string{"s"}, nil, []charclass.Class{charclass.Any}, map[string]map[rune]string{},
+		map[string][]ClassTransition{"s": {{Class: charclass.Digit.Complement(), Target: "s"}}}, "s", []string{"s"})
+
+	tests := []struct {
+		name    string
+		input   string
+		options MatchOptions
+		want    MatchResult
+	}{
+		{"accepted", "héllo wörld", MatchOptions{}, MatchResult{Accepted: true, Bytes: 13, Runes: 11}},
+		{"read to the end", "ab1cd", MatchOptions{}, MatchResult{Dead: true, Bytes: 5, Runes: 5}},
+		{"stop when dead", "äb1cd", MatchOptions{StopWhenDead: true}, MatchResult{Dead: true, Bytes: 4, Runes: 3}},
+		{"empty", "", MatchOptions{}, MatchResult{Accepted: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := letters.MatchReader(strings.NewReader(test.input), test.options)
+			if err != nil || result != test.want {
+				t.Errorf("MatchReader(%q) = %+v, %v; want %+v", test.input, result, err, test.want)
+			}
+			// a bufio.Reader is read directly
+			result, err = letters.MatchReader(bufio.NewReader(strings.NewReader(test.input)), test.options)
+			if err != nil || result != test.want {
+				t.Errorf("MatchReader(bufio %q) = %+v, %v; want %+v", test.input, result, err, test.want)
+			}
+		})
+	}
+
+	result, err := letters.MatchReader(strings.NewReader("ab\xffc"), MatchOptions{})
+	var invalid *InvalidUTF8Error
+	if !errors.As(err, &invalid) || invalid.Offset != 2 || result.Bytes != 2 {
+		t.Errorf("MatchReader(invalid UTF-8) = %+v, %v; want an error at byte 2", result, err)
+	}
+}
+
+func TestMatchReaderTokens(t *testing.T) {
+	tokens := utils.NewTokens()
+	get, _ := tokens.Rune("GET")
+	dfaTree := New([]string{"s"}, []rune{get}, map[string]map[rune]string{"s": {get: "s"}}, "s", []string{"s"})
+	dfaTree.Tokens = tokens
+	if _, err := dfaTree.MatchReader(strings.NewReader("GET"), MatchOptions{}); !errors.Is(err, ErrTokenAlphabet) {
+		t.Errorf("MatchReader() error = %v; want ErrTokenAlphabet", err)
+	}
+}
@@ -0,0 +1,38 @@
+package dfa
+
+// ShortestAccepted returns the shortest string accepted from the given state,
+// or from the start state when state is empty. Among strings of the same
+// length the first one in the order of the alphabet is returned. found is
+// false when no accepting state can be reached or the state does not exist.
+func (dfaTree *DFA) ShortestAccepted(state string) (word []rune, found bool) {
+	from, exists := dfaTree.origin(state)
+	if !exists {
+		return nil, false
+	}
+	accepting := stateSet(dfaTree.AcceptStates)
+	word, _, found = dfaTree.graph().ShortestWord([]string{from}, func(s string) bool { return accepting[s] })
+	return word, found
+}
+
+// ShortestRejected returns the shortest string over the alphabet rejected from
+// the given state, or from the start state when state is empty. A string
+// running into a missing transition is rejected as well. found is false when
+// every string is accepted or the state does not exist.
+func (dfaTree *DFA) ShortestRejected(state string) (word []rune, found bool) {
+	from, exists := dfaTree.origin(state)
+	if !exists {
+		return nil, false
+	}
+	complete := dfaTree.Complete()
+	accepting := stateSet(complete.AcceptStates)
+	word, _, found = complete.graph().ShortestWord([]string{from}, func(s string) bool { return !accepting[s] })
+	return word, found
+}
+
+// origin returns the state a query starts from, the start state if state is empty
+func (dfaTree *DFA) origin(state string) (string, bool) {
+	if state == "" {
+		return dfaTree.StartState.StateName, true
+	}
+	return state, stateExists(dfaTree.States, state)
+}
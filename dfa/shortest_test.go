@@ -0,0 +1,36 @@
+package dfa
+
+import "testing"
+
+func TestShortest(t *testing.T) {
+	// accepts the strings over a and b with an odd number of b, partial in state u
+	dfaTree := New([]string{"s", "t", "u"}, []rune("ab"),
+		map[string]map[rune]string{
+			"s": {'a': "s", 'b': "t"},
+			"t": {'a': "u", 'b': "s"},
+			"u": {'a': "t"},
+		}, "s", []string{"t", "u"})
+
+	tests := []struct {
+		state    string
+		accepted string
+		rejected string
+	}{
+		{"", "b", ""},
+		{"s", "b", ""},
+		{"t", "", "b"},
+		{"u", "", "b"}, // u has no transition on b
+	}
+	for _, test := range tests {
+		if word, found := dfaTree.ShortestAccepted(test.state); !found || string(word) != test.accepted {
+			t.Errorf("ShortestAccepted(%q) = %q, %v; want %q", test.state, string(word), found, test.accepted)
+		}
+		if word, found := dfaTree.ShortestRejected(test.state); !found || string(word) != test.rejected {
+			t.Errorf("ShortestRejected(%q) = %q, %v; want %q", test.state, string(word), found, test.rejected)
+		}
+	}
+
+	if _, found := dfaTree.ShortestAccepted("missing"); found {
+		t.Errorf("ShortestAccepted(\"missing\") found a string; want none for an unknown state")
+	}
+}
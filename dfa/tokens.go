@@ -0,0 +1,52 @@
+package dfa
+
+import "fmt"
+
+// ShareTokens returns the two DFAs over a single token table, so that a token
+// is the same symbol in both as needed to combine them. The first DFA keeps
+// its runes and the tokens of the second one are renumbered. DFAs that
+// already share their table, such as two DFAs over runes, are returned as is.
+func ShareTokens(a, b *DFA) (*DFA, *DFA, error) {
+	if a.Tokens == b.Tokens {
+		return a, b, nil
+	}
+	merged, mapping, err := a.Tokens.Merge(b.Tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	relabel := func(symbol rune) rune {
+		if r, exists := mapping[symbol]; exists {
+			return r
+		}
+		return symbol
+	}
+
+	symbols := make([]rune, 0, len(b.Symbols))
+	for _, symbol := range b.Symbols {
+		symbols = append(symbols, relabel(symbol))
+	}
+	transitions := make(map[string]map[rune]string, len(b.Transitions))
+	for state, row := range b.Transitions {
+		t := make(map[rune]string, len(row))
+		for symbol, target := range row {
+			t[relabel(symbol)] = target
+		}
+		transitions[state] = t
+	}
+	right := NewSymbolic(b.States, symbols, b.Classes, transitions, b.ClassTransitions, b.StartState.StateName, b.AcceptStates)
+	right.Tokens = merged
+
+	left := *a
+	left.Tokens = merged
+	return &left, right, nil
+}
+
+// shareTokens is ShareTokens for the operations that cannot fail. Running out
+// of runes would take more tokens than fit in memory.
+func shareTokens(a, b *DFA) (*DFA, *DFA) {
+	left, right, err := ShareTokens(a, b)
+	if err != nil {
+		panic(fmt.Sprintf("dfa: %v", err))
+	}
+	return left, right
+}
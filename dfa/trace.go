@@ -0,0 +1,20 @@
+package dfa
+
+import "github.com/dekuu5/FiniteStateMachine/utils"
+
+// Trace runs the DFA on the symbols like ValidateString and records the state
+// entered at every step. A rejection names the missing transition or the
+// non-accepting state the run ended in.
+func (dfaTree *DFA) Trace(symbols []rune) *utils.Trace {
+	current := dfaTree.StartState
+	trace := utils.NewTrace(dfaTree.Tokens, symbols, []string{current.StateName})
+	for position, symbol := range symbols {
+		next := current.Next(symbol)
+		if next == nil {
+			return trace.Stuck(position, symbol, []string{current.StateName})
+		}
+		current = next
+		trace.Enter(position, symbol, []string{current.StateName})
+	}
+	return trace.End(current.IsAccepting)
+}
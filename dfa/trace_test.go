@@ -0,0 +1,30 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestTrace(t *testing.T) {
+	// a+ with no transition on b
+	dfaTree := New([]string{"s", "t"}, []rune("ab"), map[string]map[rune]string{"s": {'a': "t"}, "t": {'a': "t"}}, "s", []string{"t"})
+
+	trace := dfaTree.Trace([]rune("aa"))
+	want := []utils.Step{{Position: -1, States: []string{"s"}}, {Position: 0, Symbol: "a", States: []string{"t"}}, {Position: 1, Symbol: "a", States: []string{"t"}}}
+	if !trace.Accepted || trace.Rejection != nil || !reflect.DeepEqual(trace.Steps, want) {
+		t.Errorf("Trace(aa) = %+v; want accepted with steps %+v", trace, want)
+	}
+
+	trace = dfaTree.Trace([]rune("aba"))
+	stuck := utils.Rejection{Reason: utils.RejectMissingTransition, States: []string{"t"}, Symbol: "b", Position: 1, Message: `no transition from state t on "b" at position 1`}
+	if trace.Accepted || trace.Rejection == nil || !reflect.DeepEqual(*trace.Rejection, stuck) || len(trace.Steps) != 2 {
+		t.Errorf("Trace(aba) = %+v, %+v; want %+v after 2 steps", trace, trace.Rejection, stuck)
+	}
+
+	trace = dfaTree.Trace(nil)
+	if trace.Accepted || trace.Rejection == nil || trace.Rejection.Reason != utils.RejectNonAccepting || trace.Rejection.Message != "the input ended in the non-accepting state s" {
+		t.Errorf("Trace() = %+v, %+v; want a rejection in the non-accepting state s", trace, trace.Rejection)
+	}
+}
@@ -2,6 +2,7 @@ package dfa
 
 import (
 	"log"
+	"github.com/dekuu5/FiniteStateMachine/charclass"
 	"github.com/dekuu5/FiniteStateMachine/utils"
 )
 
@@ -10,79 +11,196 @@ type FiniteAutomata = utils.FiniteAutomata
 
 
 
+// ValidateDfa reports whether the DFA is valid, logging every problem found
 func ValidateDfa(dfa FiniteAutomata) bool {
-	return validateStates(dfa) &&
-		validateStartState(dfa) &&
-		validateSymbols(dfa) &&
-		validateAcceptStates(dfa) &&
-		validateTransitions(dfa)
+	report := ValidateDfaReport(dfa)
+	for _, issue := range report.Issues {
+		log.Println(issue.Message)
+	}
+	return report.Valid()
+}
+
+// ValidateDfaReport runs every check and returns all the problems found
+func ValidateDfaReport(dfa FiniteAutomata) *utils.Report {
+	report := &utils.Report{}
+	validateStates(dfa, report)
+	validateStartState(dfa, report)
+	validateSymbols(dfa, report)
+	validateAcceptStates(dfa, report)
+	validateTransitions(dfa, report)
+	validateStateUsage(dfa, report)
+	return report
 }
 
 
-func validateStates(dfa FiniteAutomata) bool {
+func validateStates(dfa FiniteAutomata, report *utils.Report) {
 	if len(dfa.States) == 0 {
-		log.Println("Set of states is empty")
-		return false
+		report.Add(utils.CodeEmptyStates, utils.SeverityError, "", "", "Set of states is empty")
 	}
-	return true
 }
 
-func validateStartState(dfa FiniteAutomata) bool {
-	for _, state := range dfa.States {
-		if state == dfa.StartState {
-			return true
-		}
+func validateStartState(dfa FiniteAutomata, report *utils.Report) {
+	if !stateExists(dfa.States, dfa.StartState) {
+		report.Add(utils.CodeUnknownStartState, utils.SeverityError, dfa.StartState, "", "Start state is not in the set of states")
 	}
-	log.Println("Start state is not in the set of states")
-	return false
 }
 
-func validateSymbols(dfa FiniteAutomata) bool {
+func validateSymbols(dfa FiniteAutomata, report *utils.Report) {
 	if len(dfa.Symbols) == 0 {
-		log.Println("Set of inputs is empty")
-		return false
+		report.Add(utils.CodeEmptySymbols, utils.SeverityError, "", "", "Set of inputs is empty")
+	}
+	if !utils.ValidAlphabet(dfa.Alphabet) {
+		report.Add(utils.CodeInvalidAlphabet, utils.SeverityError, "", "", "Alphabet %q is neither %q nor %q", dfa.Alphabet, utils.AlphabetRunes, utils.AlphabetTokens)
+		return
+	}
+	for _, symbol := range dfa.Symbols {
+		if utils.ValidSymbol(dfa.Alphabet, symbol) {
+			continue
+		}
+		switch {
+		case dfa.Alphabet == utils.AlphabetTokens:
+			report.Add(utils.CodeInvalidSymbol, utils.SeverityError, "", symbol, "Input tokens must not be empty")
+		case utils.IsClassLabel(dfa.Alphabet, symbol):
+			if _, err := charclass.Parse(symbol); err != nil {
+				report.Add(utils.CodeInvalidClass, utils.SeverityError, "", symbol, "Input %q is not a valid character class: %v", symbol, err)
+			}
+		default:
+			report.Add(utils.CodeInvalidSymbol, utils.SeverityError, "", symbol, "Input %q is not a single Unicode character or a character class", symbol)
+		}
 	}
-	return true
 }
 
-func validateAcceptStates(dfa FiniteAutomata) bool {
+func validateAcceptStates(dfa FiniteAutomata, report *utils.Report) {
 	if len(dfa.AcceptStates) == 0 {
-		log.Println("Set of accepted states is empty")
-		return false
+		report.Add(utils.CodeEmptyAcceptStates, utils.SeverityWarning, "", "", "Set of accepted states is empty, the automaton accepts nothing")
 	}
 	for _, acceptState := range dfa.AcceptStates {
 		if !stateExists(dfa.States, acceptState) {
-			log.Printf("Accepted state %s is not in the set of states", acceptState)
-			return false
+			report.Add(utils.CodeUnknownAcceptState, utils.SeverityError, acceptState, "", "Accepted state %s is not in the set of states", acceptState)
 		}
 	}
-	return true
 }
 
-func validateTransitions(dfa FiniteAutomata) bool {
-	for state, transitions := range dfa.Transitions {
+func validateTransitions(dfa FiniteAutomata, report *utils.Report) {
+	alphabet := alphabetClass(dfa.Alphabet, dfa.Symbols)
+	for _, state := range utils.SortedKeys(dfa.Transitions) {
+		transitions := dfa.Transitions[state]
 		if !stateExists(dfa.States, state) {
-			log.Printf("State %s in transition table is not in the set of states", state)
-			return false
+			report.Add(utils.CodeUnknownSourceState, utils.SeverityError, state, "", "State %s in transition table is not in the set of states", state)
 		}
-		if len(transitions) != len(dfa.Symbols) {
-			log.Printf("State %s does not have transitions for all inputs", state)
-			return false
+		if !dfa.Partial && !coversSymbols(dfa.Alphabet, dfa.Symbols, transitions) {
+			report.Add(utils.CodeIncompleteTransition, utils.SeverityError, state, "", "State %s does not have transitions for all inputs", state)
 		}
-		for input, nextState := range transitions {
-			if !symbolExists(dfa.Symbols, input) {
-				log.Printf("Input %s in transition table for state %s is not in the set of inputs", input, state)
-				return false
-			}
+		inputs := utils.SortedKeys(transitions)
+		for i, input := range inputs {
+			nextState := transitions[input]
+			validateInput(dfa.Alphabet, dfa.Symbols, alphabet, state, input, report)
 			if !stateExists(dfa.States, nextState) {
-				log.Printf("Next state %s in transition table for state %s is not in the set of states", nextState, state)
-				return false
+				report.Add(utils.CodeUnknownTargetState, utils.SeverityError, state, input, "Next state %s in transition table for state %s is not in the set of states", nextState, state)
+			}
+			// overlapping inputs make the DFA nondeterministic unless they agree
+			for _, other := range inputs[:i] {
+				if transitions[other] != nextState && overlaps(dfa.Alphabet, other, input) {
+					report.Add(utils.CodeOverlappingLabels, utils.SeverityError, state, input, "Inputs %s and %s of state %s overlap but lead to different states", other, input, state)
+				}
+			}
+		}
+	}
+	if dfa.Partial {
+		return // states without transitions reject every non-empty input
+	}
+	for _, state := range dfa.States {
+		if _, exists := dfa.Transitions[state]; !exists && len(dfa.Symbols) > 0 {
+			report.Add(utils.CodeIncompleteTransition, utils.SeverityError, state, "", "State %s does not have transitions for all inputs", state)
+		}
+	}
+}
+
+// validateStateUsage warns about the states that are unreachable or dead
+func validateStateUsage(dfa FiniteAutomata, report *utils.Report) {
+	if !stateExists(dfa.States, dfa.StartState) {
+		return // every state would be unreachable
+	}
+	successors := make(map[string][]string, len(dfa.Transitions))
+	for state, transitions := range dfa.Transitions {
+		for _, target := range transitions {
+			successors[state] = append(successors[state], target)
+		}
+	}
+	utils.Analyze(dfa.States, successors, dfa.StartState, dfa.AcceptStates).AddWarnings(report)
+}
+
+// validateInput checks that a transition key is a declared input or a valid
+// character class within the declared inputs
+func validateInput(alphabetName string, symbols []string, alphabet charclass.Class, state, input string, report *utils.Report) {
+	if symbolExists(symbols, input) {
+		return
+	}
+	if utils.IsClassLabel(alphabetName, input) {
+		class, err := charclass.Parse(input)
+		if err != nil {
+			report.Add(utils.CodeInvalidClass, utils.SeverityError, state, input, "Input %s in transition table for state %s is not a valid character class: %v", input, state, err)
+			return
+		}
+		if class.Subtract(alphabet).IsEmpty() {
+			return
+		}
+	} else if r, ok := utils.SymbolRune(input); ok && alphabetName != utils.AlphabetTokens && alphabet.Contains(r) {
+		return // a single character covered by a class input
+	}
+	report.Add(utils.CodeUnknownSymbol, utils.SeverityError, state, input, "Input %s in transition table for state %s is not in the set of inputs", input, state)
+}
+
+// alphabetClass returns the runes of the declared inputs, it is empty for tokens
+func alphabetClass(alphabetName string, symbols []string) charclass.Class {
+	alphabet := charclass.Class{}
+	if alphabetName == utils.AlphabetTokens {
+		return alphabet
+	}
+	for _, symbol := range symbols {
+		if class, ok := utils.LabelClass(symbol); ok {
+			alphabet = alphabet.Union(class)
+		}
+	}
+	return alphabet
+}
+
+// coversSymbols reports whether the keys of the transitions cover every
+// declared input, either by naming it or through character classes
+func coversSymbols[V any](alphabetName string, symbols []string, transitions map[string]V) bool {
+	covered := charclass.Class{}
+	if alphabetName != utils.AlphabetTokens {
+		for input := range transitions {
+			if class, ok := utils.LabelClass(input); ok {
+				covered = covered.Union(class)
 			}
 		}
 	}
+	for _, symbol := range symbols {
+		if _, exists := transitions[symbol]; exists {
+			continue
+		}
+		if alphabetName == utils.AlphabetTokens {
+			return false
+		}
+		// invalid inputs are left to validateSymbols
+		if class, ok := utils.LabelClass(symbol); ok && !class.Subtract(covered).IsEmpty() {
+			return false
+		}
+	}
 	return true
 }
 
+// overlaps reports whether two transition keys share a rune
+func overlaps(alphabetName string, a, b string) bool {
+	if alphabetName == utils.AlphabetTokens {
+		return false // distinct tokens never overlap
+	}
+	left, leftOk := utils.LabelClass(a)
+	right, rightOk := utils.LabelClass(b)
+	return leftOk && rightOk && !left.Intersect(right).IsEmpty()
+}
+
 func stateExists(states []string, state string) bool {
 	for _, s := range states {
 		if s == state {
@@ -0,0 +1,119 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestValidateDfaReport(t *testing.T) {
+	report := ValidateDfaReport(utils.FiniteAutomata{
+		States:       []string{"s", "t"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "x",
+		AcceptStates: []string{"t", "u"},
+		Transitions: map[string]map[string]string{
+			"s": {"a": "t", "c": "z"},
+			"t": {"a": "t"},
+		},
+	})
+
+	expected := []utils.Issue{
+		{Code: utils.CodeUnknownStartState, State: "x"},
+		{Code: utils.CodeUnknownAcceptState, State: "u"},
+		{Code: utils.CodeIncompleteTransition, State: "s"},
+		{Code: utils.CodeUnknownSymbol, State: "s", Symbol: "c"},
+		{Code: utils.CodeUnknownTargetState, State: "s", Symbol: "c"},
+		{Code: utils.CodeIncompleteTransition, State: "t"},
+	}
+	got := make([]utils.Issue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		if issue.Severity != utils.SeverityError {
+			t.Errorf("issue %s has severity %s; want error", issue.Code, issue.Severity)
+		}
+		got = append(got, utils.Issue{Code: issue.Code, State: issue.State, Symbol: issue.Symbol})
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ValidateDfaReport() issues = %+v; want %+v", got, expected)
+	}
+	if report.Valid() {
+		t.Errorf("ValidateDfaReport().Valid() = true; want false")
+	}
+}
+
+func TestUnicodeSymbols(t *testing.T) {
+	automata := utils.FiniteAutomata{
+		States:       []string{"s", "t"},
+		Symbols:      []string{"α", "β", "🙂"},
+		StartState:   "s",
+		AcceptStates: []string{"t"},
+		Transitions: map[string]map[string]string{
+			"s": {"α": "t", "β": "s", "🙂": "s"},
+			"t": {"α": "t", "β": "s", "🙂": "t"},
+		},
+	}
+	if report := ValidateDfaReport(automata); !report.Valid() {
+		t.Fatalf("ValidateDfaReport() = %+v; want a valid DFA", report.Issues)
+	}
+
+	dfaTree := Constructor(automata)
+	if len(dfaTree.Symbols) != 3 {
+		t.Errorf("Constructor() symbols = %q; want all three kept", dfaTree.Symbols)
+	}
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "α", expected: true},
+		{input: "βα🙂", expected: true},
+		{input: "αβ", expected: false},
+		{input: "a", expected: false},
+	}
+	for _, tc := range testCases {
+		if result := dfaTree.ValidateString([]rune(tc.input)); result != tc.expected {
+			t.Errorf("ValidateString(%q) = %v; want %v", tc.input, result, tc.expected)
+		}
+	}
+
+	automata.Symbols = []string{"α", "ab", ""}
+	report := ValidateDfaReport(automata)
+	invalid := make([]string, 0)
+	for _, issue := range report.Issues {
+		if issue.Code == utils.CodeInvalidSymbol {
+			invalid = append(invalid, issue.Symbol)
+		}
+	}
+	if !reflect.DeepEqual(invalid, []string{"ab", ""}) {
+		t.Errorf("ValidateDfaReport() invalid symbols = %q; want [ab ]", invalid)
+	}
+}
+
+func TestPartialDfa(t *testing.T) {
+	automata := utils.FiniteAutomata{
+		States:       []string{"s", "t", "u"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "s",
+		AcceptStates: []string{"u"},
+		Transitions: map[string]map[string]string{
+			"s": {"a": "t"},
+			"t": {"b": "u"},
+		},
+	}
+
+	report := ValidateDfaReport(automata)
+	incomplete := make([]string, 0)
+	for _, issue := range report.Issues {
+		if issue.Code == utils.CodeIncompleteTransition {
+			incomplete = append(incomplete, issue.State)
+		}
+	}
+	if !reflect.DeepEqual(incomplete, []string{"s", "t", "u"}) {
+		t.Errorf("ValidateDfaReport() incomplete states = %v; want [s t u]", incomplete)
+	}
+
+	automata.Partial = true
+	if report := ValidateDfaReport(automata); !report.Valid() {
+		t.Errorf("ValidateDfaReport() of a partial DFA = %+v; want valid", report.Issues)
+	}
+}
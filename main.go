@@ -1,139 +1,198 @@
 package main
 
 import (
-	"bufio"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 
 	"github.com/dekuu5/FiniteStateMachine/dfa"
 	"github.com/dekuu5/FiniteStateMachine/nfa"
+	"github.com/dekuu5/FiniteStateMachine/regex"
 	"github.com/dekuu5/FiniteStateMachine/utils"
 )
 
+// Exit codes of every command
+const (
+	exitAccepted = 0 // every input is accepted, or the command succeeded
+	exitRejected = 1 // an input is rejected, a test case fails or the automata are not equivalent
+	exitInvalid  = 2 // the automaton, an input or the command line is invalid
+)
+
+const usage = `Usage: %[1]s <command> [flags]
+
+Commands:
+  validate   check an automaton file and print every problem found
+  run        run input strings through an automaton
+  test       run a suite of inputs with expected verdicts
+  convert    convert an automaton or a regular expression to JSON
+  minimize   print the minimal DFA of an automaton
+  equiv      check whether two automata accept the same language
+  render     print an automaton in Graphviz dot format
+  enumerate  print accepted strings in shortlex order
+
+Run '%[1]s <command> -h' for the flags of a command.
+Exit codes: 0 accepted, 1 rejected or failed, 2 invalid.
+`
+
+// commands maps the name of every command to its function, which returns the exit code
+var commands = map[string]func(args []string) int{
+	"validate":  runValidate,
+	"run":       runRun,
+	"test":      runTest,
+	"convert":   runConvert,
+	"minimize":  runMinimize,
+	"equiv":     runEquiv,
+	"render":    runRender,
+	"enumerate": runEnumerate,
+}
+
 func main() {
-	// Define command-line flags for the JSON file and type (DFA or NFA)
-	filePath := flag.String("file", "", "Path to the JSON file containing the automaton")
-	automatonType := flag.String("type", "dfa", "Type of the automaton (dfa or nfa)")
-	flag.Parse()
-
-	// Check if the file path is provided
-	if *filePath == "" {
-		log.Fatal("Please provide the path to the JSON file using the -file flag")
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		fmt.Fprintf(os.Stderr, usage, os.Args[0])
+		os.Exit(exitInvalid)
 	}
+	switch os.Args[1] {
+	case "-h", "-help", "--help", "help":
+		fmt.Printf(usage, os.Args[0])
+		return
+	}
+	command, exists := commands[os.Args[1]]
+	if !exists {
+		log.Printf("Unknown command: %s", os.Args[1])
+		fmt.Fprintf(os.Stderr, usage, os.Args[0])
+		os.Exit(exitInvalid)
+	}
+	os.Exit(command(os.Args[2:]))
+}
+
+// source holds the flags selecting the automaton a command works on
+type source struct {
+	file          string
+	automatonType string
+	pattern       string
+}
 
-	// Read the automaton from the provided JSON file
+// register adds -file, -type and -regex to the flags
+func (s *source) register(flags *flag.FlagSet) {
+	flags.StringVar(&s.file, "file", "", "Path to the JSON file containing the automaton")
+	flags.StringVar(&s.automatonType, "type", "dfa", "Type of the automaton (dfa or nfa)")
+	flags.StringVar(&s.pattern, "regex", "", "Regular expression to compile into an NFA instead of reading -file")
+}
 
-	// Validate and process based on the automaton type
-	switch strings.ToLower(*automatonType) {
-	case "dfa":
-		automatonJson := utils.ReadJson(*filePath)
+// load compiles -regex or reads and validates -file
+func (s *source) load() (dfa.Automaton, error) {
+	if s.pattern != "" {
+		nfaTree, err := regex.Compile(s.pattern)
+		if err != nil {
+			return nil, err
+		}
+		return nfaTree, nil
+	}
+	if s.file == "" {
+		return nil, errors.New("please provide the path to the JSON file using the -file flag or a pattern using the -regex flag")
+	}
+	return loadAutomaton(s.file, s.automatonType)
+}
 
+// loadAutomaton reads and validates an automaton of the given type
+func loadAutomaton(filePath string, automatonType string) (dfa.Automaton, error) {
+	switch strings.ToLower(automatonType) {
+	case "dfa":
+		automatonJson, err := utils.LoadJson(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading automaton: %v", err)
+		}
 		if valid := dfa.ValidateDfa(automatonJson); !valid {
-			log.Fatalf("Error validating the DFA")
-			os.Exit(-1)
+			return nil, fmt.Errorf("error validating the DFA %s", filePath)
 		}
-		// printDfaJson(automatonJson)
-		
-		processDfa(automatonJson)
+		return dfa.Constructor(automatonJson), nil
 	case "nfa":
-		fmt.Println("NFA")
-		automatonJson := utils.ReadJsonNfa(*filePath)
+		automatonJson, err := utils.LoadJsonNfa(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading automaton: %v", err)
+		}
 		if valid := nfa.ValidateNfa(automatonJson); !valid {
-			log.Fatalf("Error validating the NFA")
-			os.Exit(-1)
+			return nil, fmt.Errorf("error validating the NFA %s", filePath)
 		}
-		// nfaTree := nfa.Constructor(automatonJson)
-		// nfaTree := nfa.Constructor(automatonJson)
-
-		// printNfa(*nfaTree)
-		// printNfa(*nfaTree)
-		processNfa(automatonJson)
-	default:
-		log.Fatalf("Unknown automaton type: %s", *automatonType)
-		os.Exit(-1)
+		return nfa.Constructor(automatonJson), nil
 	}
+	return nil, fmt.Errorf("unknown automaton type: %s", automatonType)
 }
 
-func processDfa(dfaJson dfa.FiniteAutomata) {
-	// Loop to get the input string
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print("Enter a string to validate using the DFA: ")
-
-	// Read input until newline and trim any extra whitespace
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		fmt.Println("Error reading input:", err)
-		return
+// readSymbols converts a line of input to symbols. For token alphabets the line
+// is a JSON array or whitespace separated tokens and known is false if one of
+// them is not a symbol of the automaton
+func readSymbols(input string, tokens *utils.Tokens) (symbols []rune, known bool, err error) {
+	if tokens == nil {
+		return []rune(strings.TrimRight(input, "\r\n")), true, nil
 	}
-
-	// Remove the newline character from the end of the input
-	symbols := []rune(strings.TrimSpace(input))
-
-	fmt.Println(symbols)
-
-	dfaTree := dfa.Constructor(dfaJson)
-
-	if valid := dfaTree.ValidateString(symbols); valid {
-		fmt.Printf("String %s is accepted\n", input)
-	} else {
-		fmt.Printf("String %s is rejected\n", input)
+	words, err := utils.ParseTokens(input)
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading tokens: %v", err)
 	}
+	symbols, known = tokens.Runes(words)
+	return symbols, known, nil
 }
 
-func processNfa(nfaJson nfa.NFiniteAutomata) {
-	// Loop to get the input string
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print("Enter a string to validate using the NFA: ")
-
-	// Read input until newline and trim any extra whitespace
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		fmt.Println("Error reading input:", err)
-		return
+// accepts runs the symbols through a DFA or the set simulation of an NFA
+func accepts(automaton dfa.Automaton, symbols []rune) bool {
+	switch a := automaton.(type) {
+	case *dfa.DFA:
+		return a.ValidateString(symbols)
+	case *nfa.NFA:
+		return a.ValidateStringSet(symbols)
 	}
+	return automaton.ToDFA().ValidateString(symbols)
+}
 
-	// Remove the newline character from the end of the input
-	symbols := []rune(strings.TrimSpace(input))
-
-	fmt.Println(symbols)
-
-	nfaTree := nfa.Constructor(nfaJson)
-
-	printNfa(*nfaTree)
-	if valid := nfaTree.ValidateStringDac(symbols); valid {
-		fmt.Printf("String %s is accepted\n", input)
-	} else {
-		fmt.Printf("String %s is rejected\n", input)
-	}
-	if valid := nfaTree.ValidateStringDac(symbols); valid {
-		fmt.Printf("String %s is accepted\n", input)
-	} else {
-		fmt.Printf("String %s is rejected\n", input)
+// tokensOf returns the token table of the automaton, nil unless its inputs
+// are lines of tokens
+func tokensOf(automaton dfa.Automaton) *utils.Tokens {
+	switch a := automaton.(type) {
+	case *dfa.DFA:
+		return a.Tokens
+	case *nfa.NFA:
+		return a.Tokens
 	}
+	return automaton.ToDFA().Tokens
 }
 
-func printDfa(dfaJson dfa.DFA) {
-	fmt.Printf("States: %v\n", dfaJson.States)
-	fmt.Printf("Symbols: %v\n", dfaJson.Symbols)
-	fmt.Printf("Start State: %v\n", dfaJson.StartState)
-	fmt.Printf("Accept States: %v\n", dfaJson.AcceptStates)
-	fmt.Println("Transitions:")
-	for state, transitions := range dfaJson.Transitions {
-		fmt.Printf("  %s: %v\n", state, transitions)
+// writeAutomaton writes the automaton as dot or json
+func writeAutomaton(w io.Writer, automaton dfa.Automaton, format string) error {
+	switch strings.ToLower(format) {
+	case "dot":
+		writer := automaton.(interface{ WriteDot(w io.Writer) error })
+		return writer.WriteDot(w)
+	case "json":
+		var value interface{}
+		switch a := automaton.(type) {
+		case *dfa.DFA:
+			value = a.ToFiniteAutomata()
+		case *nfa.NFA:
+			value = a.ToNFiniteAutomata()
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
 	}
+	return fmt.Errorf("unknown format: %s", format)
 }
 
-// a problem with this function is that it doesn't print the format correctly
-func printNfa(nfaJson nfa.NFA) {
-	fmt.Printf("States: %v\n", nfaJson.States)
-	fmt.Printf("Symbols: %v\n", nfaJson.Symbols)
-	fmt.Printf("Start State: %v\n", nfaJson.StartState)
-	fmt.Printf("Accept States: %v\n", nfaJson.AcceptStates)
-	fmt.Println("Transitions:")
-	for _, state := range nfaJson.States {
-		fmt.Printf("  %s: %v\n", state, nfaJson.Transitions[state])
+// trimAutomaton removes the unreachable and dead states, listing them on stderr
+func trimAutomaton(automaton dfa.Automaton) dfa.Automaton {
+	var analysis utils.Analysis
+	switch a := automaton.(type) {
+	case *dfa.DFA:
+		analysis, automaton = a.Analyze(), a.Trim()
+	case *nfa.NFA:
+		analysis, automaton = a.Analyze(), a.Trim()
 	}
+	log.Printf("Removed unreachable states %v and dead states %v", analysis.Unreachable, analysis.Dead)
+	return automaton
 }
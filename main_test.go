@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	suite := filepath.Join(dir, "cases.txt")
+	if err := os.WriteFile(suite, []byte("+ab\n+abab\n-\n-ba\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// (ab)+ as a partial DFA, and a DFA whose start state does not exist
+	automaton := filepath.Join(dir, "ab.json")
+	if err := os.WriteFile(automaton, []byte(`{"states": ["s", "a", "b"], "symbols": ["a", "b"], "start_state": "s", "accept_states": ["b"], "partial": true,
+		"transitions": {"s": {"a": "a"}, "a": {"b": "b"}, "b": {"a": "a"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte(`{"states": ["s"], "symbols": ["a"], "start_state": "x", "accept_states": ["s"], "transitions": {"s": {"a": "s"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		run  func(args []string) int
+		args []string
+		want int
+	}{
+		{"accepted", runRun, []string{"-regex", "(ab)+", "-input", "ab", "-input", "abab"}, exitAccepted},
+		{"rejected", runRun, []string{"-regex", "(ab)+", "-input", "ab", "-input", "ba"}, exitRejected},
+		{"bad pattern", runRun, []string{"-regex", "(ab", "-input", "ab"}, exitInvalid},
+		{"missing file", runRun, []string{"-file", "does-not-exist.json", "-input", "ab"}, exitInvalid},
+		{"file accepted", runRun, []string{"-file", automaton, "-input", "ab", "-input", "abab"}, exitAccepted},
+		{"file rejected", runRun, []string{"-file", automaton, "-input", "aba"}, exitRejected},
+		{"nfa file", runRun, []string{"-file", "test.json", "-type", "nfa", "-input", "ab"}, exitAccepted},
+		{"valid file", runValidate, []string{"-file", automaton}, exitAccepted},
+		{"invalid file", runValidate, []string{"-file", broken}, exitInvalid},
+		{"file suite", runTest, []string{"-file", automaton, "-suite", suite}, exitAccepted},
+		{"suite passes", runTest, []string{"-regex", "(ab)+", "-suite", suite}, exitAccepted},
+		{"suite fails", runTest, []string{"-regex", "(ab)*", "-suite", suite}, exitRejected},
+		{"equivalent", runEquiv, []string{"-regex", "a*", "-other-regex", "(a|aa)*"}, exitAccepted},
+		{"not equivalent", runEquiv, []string{"-regex", "a*", "-other-regex", "a+"}, exitRejected},
+		{"equivalent files", runEquiv, []string{"-file", automaton, "-other-regex", "(ab)+"}, exitAccepted},
+		{"missing other", runEquiv, []string{"-file", automaton}, exitInvalid},
+		{"unknown state", runEnumerate, []string{"-regex", "a", "-shortest-accepted", "-from", "nowhere"}, exitInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.run(test.args); got != test.want {
+				t.Errorf("exit code = %d; want %d", got, test.want)
+			}
+		})
+	}
+}
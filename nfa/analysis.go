@@ -0,0 +1,80 @@
+package nfa
+
+import (
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+/**
+ * This function finds the states of the NFA that cannot be reached from the start state
+ * and the ones that cannot reach an accepting state, following epsilon moves as well
+ * @return The unreachable and dead states in the order of States
+ */
+func (nfa *NFA) Analyze() utils.Analysis {
+	return utils.Analyze(nfa.States, nfa.successors(), nfa.StartState.StateName, nfa.AcceptStates)
+}
+
+/**
+ * This function removes the unreachable and dead states together with their transitions
+ * The start state is always kept, so a NFA accepting nothing is trimmed down to it
+ * @return A pointer to the trimmed NFA
+ */
+func (nfa *NFA) Trim() *NFA {
+	useless := nfa.Analyze().Useless()
+	start := nfa.StartState.StateName
+	keep := func(state string) bool { return state == start || !useless[state] }
+	// kept returns the targets that are not removed
+	kept := func(targets []string) []string {
+		result := make([]string, 0, len(targets))
+		for _, target := range targets {
+			if keep(target) {
+				result = append(result, target)
+			}
+		}
+		return result
+	}
+
+	states := make([]string, 0, len(nfa.States))
+	transitions := make(map[string]map[rune][]string)
+	classTransitions := make(map[string][]ClassTransition)
+	for _, state := range nfa.States {
+		if !keep(state) {
+			continue
+		}
+		states = append(states, state)
+		transitions[state] = make(map[rune][]string)
+		for symbol, targets := range nfa.Transitions[state] {
+			if targets := kept(targets); len(targets) > 0 {
+				transitions[state][symbol] = targets
+			}
+		}
+		for _, edge := range nfa.ClassTransitions[state] {
+			if targets := kept(edge.Targets); len(targets) > 0 {
+				classTransitions[state] = append(classTransitions[state], ClassTransition{Class: edge.Class, Targets: targets})
+			}
+		}
+	}
+
+	symbols := append([]rune{}, nfa.Symbols...)
+	classes := append([]charclass.Class{}, nfa.Classes...)
+	trimmed := NewSymbolic(states, symbols, classes, transitions, classTransitions, start, kept(nfa.AcceptStates))
+	trimmed.Epsilon = nfa.epsilon()
+	trimmed.Tokens = nfa.Tokens
+	return trimmed
+}
+
+// successors lists the targets of the transitions of every state, epsilon moves included
+func (nfa *NFA) successors() map[string][]string {
+	successors := make(map[string][]string, len(nfa.States))
+	for state, transitions := range nfa.Transitions {
+		for _, targets := range transitions {
+			successors[state] = append(successors[state], targets...)
+		}
+	}
+	for state, edges := range nfa.ClassTransitions {
+		for _, edge := range edges {
+			successors[state] = append(successors[state], edge.Targets...)
+		}
+	}
+	return successors
+}
@@ -0,0 +1,41 @@
+package nfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestAnalyzeAndTrim(t *testing.T) {
+	// q2 is only reached through an epsilon move, q3 loops forever and q4 is never entered
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3", "q4"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q1", "q3"}},
+			"q1": {"_": {"q2"}},
+			"q3": {"b": {"q3"}},
+			"q4": {"a": {"q2"}},
+		},
+	})
+
+	analysis := nfa.Analyze()
+	if !reflect.DeepEqual(analysis.Unreachable, []string{"q4"}) || !reflect.DeepEqual(analysis.Dead, []string{"q3"}) {
+		t.Errorf("Analyze() = %+v; want q4 unreachable and q3 dead", analysis)
+	}
+
+	trimmed := nfa.Trim()
+	if !reflect.DeepEqual(trimmed.States, []string{"q0", "q1", "q2"}) {
+		t.Errorf("Trim().States = %v; want [q0 q1 q2]", trimmed.States)
+	}
+	if got := trimmed.Transitions["q0"]['a']; !reflect.DeepEqual(got, []string{"q1"}) {
+		t.Errorf("Trim() moves from q0 on a to %v; want [q1]", got)
+	}
+	if equivalent, counterexample := dfa.Equivalent(nfa, trimmed); !equivalent {
+		t.Errorf("Trim() differs on %q", string(counterexample))
+	}
+}
@@ -0,0 +1,73 @@
+package nfa
+
+import "github.com/dekuu5/FiniteStateMachine/charclass"
+
+/**
+ * ClassTransition is a transition taken on every rune of a character class
+ * Class: The runes the transition is taken on
+ * Targets: The names of the next states
+ */
+type ClassTransition struct {
+	Class   charclass.Class
+	Targets []string
+}
+
+// ClassEdge is a class transition of the node graph
+type ClassEdge struct {
+	Class   charclass.Class
+	Targets []*StateNode
+}
+
+/**
+ * This function returns the nodes reached from the node by reading the symbol
+ * Epsilon moves are not followed, and the epsilon rune read as input only matches classes
+ * @param symbol: The symbol to read
+ * @param epsilon: The rune that labels epsilon moves
+ * @return The targets of the transitions on the symbol and of the classes containing it
+ */
+func (node *StateNode) next(symbol rune, epsilon rune) []*StateNode {
+	targets := make([]*StateNode, 0)
+	if symbol != epsilon {
+		targets = append(targets, node.Transitions[symbol]...)
+	}
+	for _, edge := range node.ClassTransitions {
+		if edge.Class.Contains(symbol) {
+			targets = append(targets, edge.Targets...)
+		}
+	}
+	return targets
+}
+
+/**
+ * This function is StateNode.next on the transition table
+ * @param state: The name of the state to move from
+ * @param symbol: The symbol to read
+ * @return The names of the next states, possibly with duplicates
+ */
+func (nfa *NFA) next(state string, symbol rune) []string {
+	targets := make([]string, 0)
+	if symbol != nfa.epsilon() {
+		targets = append(targets, nfa.Transitions[state][symbol]...)
+	}
+	for _, edge := range nfa.ClassTransitions[state] {
+		if edge.Class.Contains(symbol) {
+			targets = append(targets, edge.Targets...)
+		}
+	}
+	return targets
+}
+
+/**
+ * This function splits the alphabet of the NFA into disjoint classes, see charclass.Alphabet
+ * For a NFA without classes it is one class per input symbol in the order of Symbols
+ * @return The classes, the smallest rune of each one stands for all of it
+ */
+func (nfa *NFA) alphabet() []charclass.Class {
+	labels := make([]charclass.Class, 0)
+	for _, state := range nfa.States {
+		for _, edge := range nfa.ClassTransitions[state] {
+			labels = append(labels, edge.Class)
+		}
+	}
+	return charclass.Alphabet(nfa.inputSymbols(), nfa.Classes, labels)
+}
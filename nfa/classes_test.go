@@ -0,0 +1,65 @@
+package nfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestClassTransitions(t *testing.T) {
+	// words made of \w runes ending in a digit, '_' is both an epsilon move and part of \w
+	automata := utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{`\w`},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {`\w`: {"q0"}, "[0-9]": {"q1"}},
+			"q1": {"_": {"q2"}},
+		},
+	}
+	if report := ValidateNfaReport(automata); !report.Valid() {
+		t.Fatalf("ValidateNfaReport() = %+v; want a valid NFA", report.Issues)
+	}
+	nfa := Constructor(automata)
+	dfa := nfa.ToDFA()
+
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "7", expected: true},
+		{input: "a_b9", expected: true},
+		{input: "_", expected: false},
+		{input: "9_", expected: false},
+		{input: "", expected: false},
+		{input: "a-9", expected: false},
+	}
+	for _, tc := range testCases {
+		input := []rune(tc.input)
+		if got := nfa.ValidateStringSet(input); got != tc.expected {
+			t.Errorf("ValidateStringSet(%q) = %v; want %v", tc.input, got, tc.expected)
+		}
+		if got := dfa.ValidateString(input); got != tc.expected {
+			t.Errorf("ToDFA().ValidateString(%q) = %v; want %v", tc.input, got, tc.expected)
+		}
+	}
+
+	// \w is split into the digits and the rest
+	if len(dfa.Symbols) != 0 || len(dfa.Classes) != 2 {
+		t.Errorf("ToDFA() alphabet = %q, %v; want two classes", dfa.Symbols, dfa.Classes)
+	}
+
+	report := ValidateNfaReport(utils.NFiniteAutomata{
+		States:       []string{"q0"},
+		Symbols:      []string{"[a-z]"},
+		StartState:   "q0",
+		AcceptStates: []string{"q0"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"[a-f]": {"q0"}, "x": {"q0"}, `\d`: {"q0"}, "[a": {"q0"}},
+		},
+	})
+	if len(report.Issues) != 2 || report.Issues[0].Symbol != "[a" || report.Issues[1].Symbol != `\d` {
+		t.Errorf("ValidateNfaReport() issues = %+v; want \"[a\" and \\d rejected", report.Issues)
+	}
+}
@@ -0,0 +1,56 @@
+package nfa
+
+import (
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+/**
+ * This function checks whether the NFA accepts no string at all, without determinizing it
+ * @return true if the language is empty, otherwise false and the shortest accepted string
+ */
+func (nfa *NFA) IsEmpty() (bool, []rune) {
+	word, found := nfa.ShortestAccepted("")
+	return !found, word
+}
+
+/**
+ * This function checks whether the NFA accepts finitely many strings, without determinizing it
+ * The language is infinite when a cycle reading at least one symbol goes through states that
+ * are reachable and can reach an accepting state, cycles of epsilon moves do not count
+ * @return true if the language is finite, otherwise false and a loop that can be pumped
+ */
+func (nfa *NFA) IsFinite() (bool, *utils.Loop) {
+	loop := nfa.graph().FindLoop(nfa.States, nfa.StartState.StateName, nfa.AcceptStates)
+	return loop == nil, loop
+}
+
+/**
+ * This function checks whether the NFA accepts every string over its input symbols
+ * Unlike the other checks this one needs the subset construction
+ * @return true if the language is universal, otherwise false and the shortest rejected string
+ */
+func (nfa *NFA) IsUniversal() (bool, []rune) {
+	return nfa.ToDFA().IsUniversal()
+}
+
+/**
+ * This function builds the transition graph of the NFA
+ * There is one edge per class of the alphabet and target, labelled by the smallest rune of the class
+ * @return The graph with the epsilon moves as epsilon edges
+ */
+func (nfa *NFA) graph() utils.Graph {
+	alphabet := nfa.alphabet()
+	epsilon := nfa.epsilon()
+	graph := make(utils.Graph, len(nfa.States))
+	for _, state := range nfa.States {
+		for _, target := range nfa.Transitions[state][epsilon] {
+			graph[state] = append(graph[state], utils.Edge{Target: target, Epsilon: true})
+		}
+		for _, class := range alphabet {
+			for _, target := range nfa.next(state, class.Min()) {
+				graph[state] = append(graph[state], utils.Edge{Target: target, Symbol: class.Min()})
+			}
+		}
+	}
+	return graph
+}
@@ -0,0 +1,56 @@
+package nfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestDecide(t *testing.T) {
+	// q3 loops on b but never reaches an accepting state, the epsilon cycle
+	// between q1 and q2 reads nothing, so the language {a, ab} is finite
+	finite := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3", "q4"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2", "q4"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q1", "q3"}},
+			"q1": {"_": {"q2"}, "b": {"q4"}},
+			"q2": {"_": {"q1"}},
+			"q3": {"b": {"q3"}},
+		},
+	})
+	if empty, accepted := finite.IsEmpty(); empty || string(accepted) != "a" {
+		t.Errorf("IsEmpty() = %v, %q; want false, \"a\"", empty, string(accepted))
+	}
+	if isFinite, loop := finite.IsFinite(); !isFinite {
+		t.Errorf("IsFinite() = false, %+v; want true", loop)
+	}
+	if universal, rejected := finite.IsUniversal(); universal || string(rejected) != "" {
+		t.Errorf("IsUniversal() = %v, %q; want false, \"\"", universal, string(rejected))
+	}
+
+	// every non-empty string that does not start with a digit
+	letters := NewSymbolic([]string{"q0", "q1"}, nil, []charclass.Class{charclass.Any},
+		map[string]map[rune][]string{"q0": {}, "q1": {}},
+		map[string][]ClassTransition{
+			"q0": {{Class: charclass.Digit.Complement(), Targets: []string{"q1"}}},
+			"q1": {{Class: charclass.Any, Targets: []string{"q1"}}},
+		}, "q0", []string{"q1"})
+	isFinite, loop := letters.IsFinite()
+	if isFinite || len(loop.Cycle) == 0 {
+		t.Fatalf("IsFinite() = %v, %+v; want an infinite language", isFinite, loop)
+	}
+	for k := 0; k < 4; k++ {
+		word := string(loop.Prefix) + strings.Repeat(string(loop.Cycle), k) + string(loop.Suffix)
+		if !letters.ValidateStringSet([]rune(word)) {
+			t.Errorf("IsFinite() loop %+v pumped %d times gives rejected %q", loop, k, word)
+		}
+	}
+	if universal, rejected := letters.IsUniversal(); universal || string(rejected) != "" {
+		t.Errorf("IsUniversal() = %v, %q; want false, \"\"", universal, string(rejected))
+	}
+}
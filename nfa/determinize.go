@@ -0,0 +1,123 @@
+package nfa
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+)
+
+/**
+ * This function converts the NFA into an equivalent DFA using the subset construction
+ * Every DFA state is a set of NFA states and is named after its members, e.g. "{q0,q2}"
+ * The empty set "{}" is added as a sink state whenever a subset has no move on a symbol
+ * so the resulting DFA is always complete
+ * Character classes are split into disjoint classes first, each one is handled as a single symbol
+ * @return A pointer to the constructed DFA
+ */
+func (nfa *NFA) ToDFA() *dfa.DFA {
+	alphabet := nfa.alphabet()           // the alphabet without the epsilon symbol, as disjoint classes
+	accepting := nfa.acceptingStateSet() // set of the accepting NFA states
+
+	start := nfa.EpsilonClosure([]string{nfa.StartState.StateName})
+
+	states := make([]string, 0)
+	acceptStates := make([]string, 0)
+	transitions := make(map[string]map[rune]string)
+
+	seen := map[string]bool{}
+	queue := [][]string{start}
+	seen[subsetName(start)] = true
+
+	for len(queue) > 0 { // breadth first over the reachable subsets
+		subset := queue[0]
+		queue = queue[1:]
+
+		name := subsetName(subset)
+		states = append(states, name)
+		transitions[name] = make(map[rune]string)
+
+		for _, state := range subset {
+			if accepting[state] { // the subset accepts if any of its members accepts
+				acceptStates = append(acceptStates, name)
+				break
+			}
+		}
+
+		for _, class := range alphabet {
+			symbol := class.Min() // the smallest rune stands for the whole class
+			next := nfa.EpsilonClosure(nfa.move(subset, symbol))
+			nextName := subsetName(next)
+			transitions[name][symbol] = nextName
+			if !seen[nextName] {
+				seen[nextName] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	dfaTree := dfa.NewFromAlphabet(states, alphabet, transitions, subsetName(start), acceptStates)
+	dfaTree.Tokens = nfa.Tokens
+	return dfaTree
+}
+
+/**
+ * This function returns the states reachable from the given states by reading the symbol once
+ * @param states: The states to move from
+ * @param symbol: The symbol to read
+ * @return The target states without duplicates
+ */
+func (nfa *NFA) move(states []string, symbol rune) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0)
+	for _, state := range states {
+		for _, next := range nfa.next(state, symbol) {
+			if !seen[next] {
+				seen[next] = true
+				result = append(result, next)
+			}
+		}
+	}
+	return result
+}
+
+// inputSymbols returns the symbols of the NFA without the epsilon symbol
+func (nfa *NFA) inputSymbols() []rune {
+	symbols := make([]rune, 0, len(nfa.Symbols))
+	for _, symbol := range nfa.Symbols {
+		if symbol != nfa.epsilon() {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// stateOrder maps every state name to its position in the States slice
+func (nfa *NFA) stateOrder() map[string]int {
+	order := make(map[string]int, len(nfa.States))
+	for i, state := range nfa.States {
+		order[state] = i
+	}
+	return order
+}
+
+// acceptingStateSet returns the accepting states as a set
+func (nfa *NFA) acceptingStateSet() map[string]bool {
+	accepting := make(map[string]bool, len(nfa.AcceptStates))
+	for _, state := range nfa.AcceptStates {
+		accepting[state] = true
+	}
+	return accepting
+}
+
+// sortStates sorts the states by their declaration order
+func sortStates(states []string, order map[string]int) {
+	sort.Slice(states, func(i, j int) bool {
+		return order[states[i]] < order[states[j]]
+	})
+}
+
+// subsetName builds the DFA state name of a set of NFA states, e.g. "{q0,q1}"
+func subsetName(states []string) string {
+	return "{" + strings.Join(states, ",") + "}"
+}
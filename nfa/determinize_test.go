@@ -0,0 +1,56 @@
+package nfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestToDFA(t *testing.T) {
+	// q0 and q2 form an epsilon cycle
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b", "_"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q1"}, "_": {"q2"}},
+			"q1": {"b": {"q2"}},
+			"q2": {"a": {"q2"}, "_": {"q0"}},
+		},
+	})
+
+	dfa := nfa.ToDFA()
+
+	if got := dfa.StartState.StateName; got != "{q0,q2}" {
+		t.Errorf("start state = %s; want {q0,q2}", got)
+	}
+	if len(dfa.Symbols) != 2 {
+		t.Errorf("symbols = %q; want the epsilon symbol removed", dfa.Symbols)
+	}
+	for _, state := range dfa.States {
+		if len(dfa.Transitions[state]) != len(dfa.Symbols) {
+			t.Errorf("state %s has %d transitions; want %d", state, len(dfa.Transitions[state]), len(dfa.Symbols))
+		}
+	}
+
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "", expected: true},
+		{input: "a", expected: true},
+		{input: "b", expected: false},
+		{input: "ab", expected: true},
+		{input: "ba", expected: false},
+		{input: "aab", expected: true},
+		{input: "abb", expected: false},
+		{input: "abab", expected: true},
+	}
+
+	for _, tc := range testCases {
+		if result := dfa.ValidateString([]rune(tc.input)); result != tc.expected {
+			t.Errorf("ToDFA().ValidateString(%q) = %v; want %v", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package nfa
+
+import (
+	"io"
+	"sort"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+/**
+ * This function writes the NFA as a Graphviz digraph
+ * Accepting states are drawn as double circles and the start state has an incoming arrow
+ * All the transitions between two states share one edge labelled with their symbols and classes,
+ * epsilon moves are labelled ε whatever symbol the NFA uses for them and come last
+ * @param w: The writer the digraph is written to
+ * @return The error of the writer, if any
+ */
+func (nfa *NFA) WriteDot(w io.Writer) error {
+	graph := utils.NewDotGraph("NFA", nfa.States, nfa.StartState.StateName, nfa.AcceptStates)
+	epsilon := nfa.epsilon()
+	for _, state := range nfa.States {
+		row := nfa.Transitions[state]
+		symbols := make([]rune, 0, len(row))
+		for symbol := range row {
+			if symbol != epsilon {
+				symbols = append(symbols, symbol)
+			}
+		}
+		sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+		for _, symbol := range symbols {
+			for _, target := range row[symbol] {
+				graph.AddLabel(state, target, utils.DotSymbol(nfa.Tokens, symbol))
+			}
+		}
+		for _, edge := range nfa.ClassTransitions[state] {
+			for _, target := range edge.Targets {
+				graph.AddLabel(state, target, edge.Class.String())
+			}
+		}
+	}
+	// epsilon moves are added last so that ε ends the labels
+	for _, state := range nfa.States {
+		for _, target := range nfa.Transitions[state][epsilon] {
+			graph.AddLabel(state, target, "ε")
+		}
+	}
+	return graph.Write(w)
+}
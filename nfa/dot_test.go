@@ -0,0 +1,37 @@
+package nfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestWriteDot(t *testing.T) {
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q1"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q1"}, "b": {"q0", "q1"}, "_": {"q1"}},
+		},
+	})
+
+	var out strings.Builder
+	if err := nfa.WriteDot(&out); err != nil {
+		t.Fatalf("WriteDot() returned error %v", err)
+	}
+
+	for _, expected := range []string{
+		"\t\"q0\" [shape=circle];\n",
+		"\t\"q1\" [shape=doublecircle];\n",
+		"\t\"__start\" -> \"q0\";\n",
+		"\t\"q0\" -> \"q0\" [label=\"b\"];\n",
+		"\t\"q0\" -> \"q1\" [label=\"a,b,ε\"];\n",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("WriteDot() output is missing %q:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package nfa
+
+import "github.com/dekuu5/FiniteStateMachine/dfa"
+
+/**
+ * This function enumerates the accepted strings of the NFA in shortlex order, see dfa.Enumerate
+ * @param maxLength: The length of the longest string to yield, negative for no limit
+ * @param maxCount: The number of strings to yield, negative for no limit
+ * @return The iterator, running over the determinized NFA
+ */
+func (nfa *NFA) Enumerate(maxLength, maxCount int) *dfa.Enumerator {
+	return nfa.ToDFA().Enumerate(maxLength, maxCount)
+}
@@ -0,0 +1,42 @@
+package nfa
+
+/**
+ * This function returns the given states together with every state reachable through epsilon moves
+ * Each state is visited once, so epsilon cycles terminate
+ * The result is sorted in the order the states are declared in the NFA
+ * @param states: The states to start from
+ * @return The epsilon closure of the states
+ */
+func (nfa *NFA) EpsilonClosure(states []string) []string {
+	seen := map[string]bool{}
+	stack := make([]string, 0, len(states))
+	result := make([]string, 0, len(states))
+	for _, state := range states {
+		if !seen[state] {
+			seen[state] = true
+			stack = append(stack, state)
+		}
+	}
+	epsilon := nfa.epsilon()
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		result = append(result, state)
+		for _, next := range nfa.Transitions[state][epsilon] {
+			if !seen[next] { // states already visited are skipped so epsilon cycles terminate
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	sortStates(result, nfa.stateOrder())
+	return result
+}
+
+// epsilon returns the rune used for epsilon moves, NFAs built by hand default to EpsilonSymbol
+func (nfa *NFA) epsilon() rune {
+	if nfa.Epsilon == 0 {
+		return EpsilonSymbol
+	}
+	return nfa.Epsilon
+}
@@ -0,0 +1,61 @@
+package nfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestEpsilonClosure(t *testing.T) {
+	// both default spellings of epsilon are used, q1 and q2 form an epsilon cycle
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3"},
+		Symbols:      []string{"a"},
+		StartState:   "q0",
+		AcceptStates: []string{"q3"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"_": {"q1"}},
+			"q1": {"ε": {"q2"}},
+			"q2": {"_": {"q1"}, "a": {"q3"}},
+		},
+	})
+
+	testCases := []struct {
+		states   []string
+		expected []string
+	}{
+		{states: []string{"q0"}, expected: []string{"q0", "q1", "q2"}},
+		{states: []string{"q2"}, expected: []string{"q1", "q2"}},
+		{states: []string{"q3"}, expected: []string{"q3"}},
+		{states: []string{"q3", "q1"}, expected: []string{"q1", "q2", "q3"}},
+		{states: []string{}, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		if result := nfa.EpsilonClosure(tc.states); !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("EpsilonClosure(%v) = %v; want %v", tc.states, result, tc.expected)
+		}
+	}
+}
+
+func TestConfiguredEpsilon(t *testing.T) {
+	// with a configured epsilon "_" is an ordinary input symbol
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1"},
+		Symbols:      []string{"_", "λ"},
+		StartState:   "q0",
+		AcceptStates: []string{"q1"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"λ": {"q1"}, "_": {"q0"}},
+		},
+		Epsilon: "λ",
+	})
+
+	if !reflect.DeepEqual(nfa.Symbols, []rune{'_'}) {
+		t.Errorf("Symbols = %q; want [_]", nfa.Symbols)
+	}
+	if result := nfa.EpsilonClosure([]string{"q0"}); !reflect.DeepEqual(result, []string{"q0", "q1"}) {
+		t.Errorf("EpsilonClosure([q0]) = %v; want [q0 q1]", result)
+	}
+}
@@ -0,0 +1,71 @@
+package nfa
+
+import "github.com/dekuu5/FiniteStateMachine/dfa"
+
+/**
+ * This is the dfa.Matcher of a NFA, advancing the set of active states like ValidateStringSet
+ * States that cannot reach an accepting state are dropped from the set as soon as they are
+ * entered, so the matcher is dead exactly when the set is empty
+ */
+type setMatcher struct {
+	start   []*StateNode // the epsilon closure of the start state without the dead states
+	active  []*StateNode
+	epsilon rune
+	dead    map[string]bool
+}
+
+/**
+ * This function returns a matcher in the start state of the NFA
+ * The states that cannot reach an accepting state are found once here
+ * @return The matcher, see dfa.Matcher
+ */
+func (nfa *NFA) NewMatcher() dfa.Matcher {
+	m := &setMatcher{epsilon: nfa.epsilon(), dead: make(map[string]bool)}
+	for _, state := range nfa.Analyze().Dead {
+		m.dead[state] = true
+	}
+	if nfa.StartState != nil {
+		m.start = m.alive(nodeClosure([]*StateNode{nfa.StartState}, m.epsilon))
+	}
+	m.active = m.start
+	return m
+}
+
+func (m *setMatcher) Step(symbol rune) bool {
+	if len(m.active) > 0 {
+		m.active = m.alive(advance(m.active, symbol, m.epsilon))
+	}
+	return len(m.active) > 0
+}
+
+func (m *setMatcher) Feed(symbols []rune) bool {
+	for _, symbol := range symbols {
+		if !m.Step(symbol) {
+			return false // an empty set stays empty
+		}
+	}
+	return len(m.active) > 0
+}
+
+func (m *setMatcher) Accepting() bool {
+	return anyAccepting(m.active)
+}
+
+func (m *setMatcher) Dead() bool {
+	return len(m.active) == 0
+}
+
+func (m *setMatcher) Reset() {
+	m.active = m.start
+}
+
+// alive drops the dead states from the nodes
+func (m *setMatcher) alive(nodes []*StateNode) []*StateNode {
+	result := make([]*StateNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !m.dead[node.StateName] {
+			result = append(result, node)
+		}
+	}
+	return result
+}
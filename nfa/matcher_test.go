@@ -0,0 +1,43 @@
+package nfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestMatcher(t *testing.T) {
+	// ab followed by any number of c, q3 is a dead branch entered on a
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2", "q3"},
+		Symbols:      []string{"a", "b", "c"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q1", "q3"}},
+			"q1": {"b": {"q2"}},
+			"q2": {"c": {"q2"}, "_": {"q2"}},
+			"q3": {"a": {"q3"}, "b": {"q3"}},
+		},
+	})
+
+	m := nfa.NewMatcher()
+	if m.Dead() || m.Accepting() {
+		t.Fatalf("new matcher: Accepting() = %v, Dead() = %v; want false, false", m.Accepting(), m.Dead())
+	}
+	if !m.Feed([]rune("abcc")) || !m.Accepting() {
+		t.Errorf("Feed(abcc) left the matcher dead or rejecting")
+	}
+	m.Reset()
+	// the branch through q3 can read aa but never accept, so the matcher dies right away
+	if m.Feed([]rune("aa")) || !m.Dead() {
+		t.Errorf("Feed(aa) = true; want dead although q3 is still active")
+	}
+	m.Reset()
+	for _, symbol := range "ab" {
+		m.Step(symbol)
+	}
+	if !m.Accepting() {
+		t.Errorf("Step(a), Step(b) after Reset() is not accepting")
+	}
+}
@@ -2,7 +2,9 @@ package nfa
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/dekuu5/FiniteStateMachine/charclass"
 	"github.com/dekuu5/FiniteStateMachine/utils"
 )
 
@@ -11,13 +13,15 @@ import (
  * It has the following fields:
  * StateName: The name of the state
  * Transitions: A map of runes to a slice of pointers to StateNodes
+ * ClassTransitions: The transitions labelled with character classes
  * IsAccepting: A boolean that indicates if the state is an accepting state
  */
 
 type StateNode struct {
-	StateName   string
-	Transitions map[rune][]*StateNode
-	IsAccepting bool
+	StateName        string
+	Transitions      map[rune][]*StateNode
+	ClassTransitions []ClassEdge
+	IsAccepting      bool
 }
 
 /**
@@ -28,24 +32,40 @@ type StateNode struct {
  * Transitions: A map of strings to a map of runes to a slice of strings
  * StartState: A pointer to the start state of the NFA
  * AcceptStates: A slice of strings that represents the accepting states of the NFA
+ * Epsilon: The rune that labels epsilon moves, EpsilonSymbol when left empty
+ * Classes: The character classes of the alphabet, next to Symbols
+ * ClassTransitions: The transitions labelled with character classes, by source state
+ * Tokens: The names of the symbols when the alphabet is made of tokens, nil for runes
  */
 type NFA struct {
-	States       []string
-	Symbols      []rune
-	Transitions  map[string]map[rune][]string
-	StartState   *StateNode
-	AcceptStates []string
+	States           []string
+	Symbols          []rune
+	Transitions      map[string]map[rune][]string
+	StartState       *StateNode
+	AcceptStates     []string
+	Epsilon          rune
+	Classes          []charclass.Class
+	ClassTransitions map[string][]ClassTransition
+	Tokens           *utils.Tokens
 }
 
+// EpsilonSymbol is the rune used for epsilon moves unless the automaton configures its own
+const EpsilonSymbol = '_'
+
+
 /**
  * This function constructs the nodes of the NFA
- * @param jsonInput: A NFiniteAutomata struct that represents the NFA
+ * @param states: The states of the NFA
+ * @param transitions: The transition table of the NFA
+ * @param classTransitions: The transitions labelled with character classes
+ * @param startState: The name of the start state
+ * @param acceptStates: The names of the accepting states
  * @return A pointer to the start state of the NFA
  */
-func constructNodes(jsonInput utils.NFiniteAutomata) *StateNode {
+func constructNodes(states []string, transitions map[string]map[rune][]string, classTransitions map[string][]ClassTransition, startState string, acceptStates []string) *StateNode {
 	nodes := make(map[string]*StateNode) // map of state name to StateNode
 
-	for _, state := range jsonInput.States { // loop through the states and create a StateNode for each state
+	for _, state := range states { // loop through the states and create a StateNode for each state
 		nodes[state] = &StateNode{
 			StateName:   state,                       // set the state name
 			Transitions: make(map[rune][]*StateNode), // create a map of runes to a slice of pointers to StateNodes
@@ -53,78 +73,186 @@ func constructNodes(jsonInput utils.NFiniteAutomata) *StateNode {
 		}
 	}
 
-	for _, acceptState := range jsonInput.AcceptStates { // loop through the accepting states and set the IsAccepting field to true
+	for _, acceptState := range acceptStates { // loop through the accepting states and set the IsAccepting field to true
 		if node, exists := nodes[acceptState]; exists { //check if the state exists in the nodes map
 			node.IsAccepting = true // set the IsAccepting field to true
 		}
 
 	}
 
-	for state, transition := range jsonInput.Transitions { // loop through the transitions and set the transitions of each state
+	for state, transition := range transitions { // loop through the transitions and set the transitions of each state
 		for symbol, targetStates := range transition { // loop through the target states of each symbol
-			if len(symbol) == 1 {
-				for _, targetState := range targetStates {
-					// append the target state to the transitions of the state
-					nodes[state].Transitions[rune(symbol[0])] = append(nodes[state].Transitions[rune(symbol[0])], nodes[targetState])
-				}
+			for _, targetState := range targetStates {
+				// append the target state to the transitions of the state
+				nodes[state].Transitions[symbol] = append(nodes[state].Transitions[symbol], nodes[targetState])
 			}
 		}
 	}
 
-	return nodes[jsonInput.StartState]
+	for state, edges := range classTransitions { // the class transitions keep their order
+		for _, edge := range edges {
+			targets := make([]*StateNode, 0, len(edge.Targets))
+			for _, targetState := range edge.Targets {
+				targets = append(targets, nodes[targetState])
+			}
+			nodes[state].ClassTransitions = append(nodes[state].ClassTransitions, ClassEdge{Class: edge.Class, Targets: targets})
+		}
+	}
+
+	return nodes[startState]
 
 }
 
+/**
+ * This function builds a NFA directly from its rune keyed transition table
+ * It is used by Constructor and by the algorithms that generate new automata
+ * @param states: The states of the NFA
+ * @param symbols: The input symbols of the NFA, without the epsilon symbol
+ * @param transitions: The transition table, epsilon moves are keyed by EpsilonSymbol
+ * @param startState: The name of the start state
+ * @param acceptStates: The names of the accepting states
+ * @return A pointer to the constructed NFA struct
+ */
+func New(states []string, symbols []rune, transitions map[string]map[rune][]string, startState string, acceptStates []string) *NFA {
+	return NewSymbolic(states, symbols, nil, transitions, nil, startState, acceptStates)
+}
+
+/**
+ * This function is New for NFAs whose alphabet and transitions also have character classes
+ * @param classes: The character classes of the alphabet
+ * @param classTransitions: The transitions labelled with character classes, by source state
+ * @return A pointer to the constructed NFA struct
+ */
+func NewSymbolic(states []string, symbols []rune, classes []charclass.Class, transitions map[string]map[rune][]string, classTransitions map[string][]ClassTransition, startState string, acceptStates []string) *NFA {
+	return &NFA{
+		States:           states,
+		Symbols:          symbols,
+		Transitions:      transitions,
+		StartState:       constructNodes(states, transitions, classTransitions, startState, acceptStates),
+		AcceptStates:     acceptStates,
+		Epsilon:          EpsilonSymbol,
+		Classes:          classes,
+		ClassTransitions: classTransitions,
+	}
+}
+
 /**
  * This is the constructor function for the NFA struct
  * It constructs the NFA struct from a NFiniteAutomata struct
+ * Every spelling of the epsilon symbol accepted by the NFiniteAutomata is stored as a single rune
  * @param jsonInput: A NFiniteAutomata struct that represents the NFA
  * @return A pointer to the constructed NFA struct
  */
 func Constructor(jsonInput utils.NFiniteAutomata) *NFA {
+	// the rune used for epsilon moves, the configured symbol if it is a single rune
+	epsilon := EpsilonSymbol
+	tokens := jsonInput.TokenTable()
+	if r, err := jsonInput.ParseSymbol(jsonInput.Epsilon, tokens); err == nil {
+		epsilon = r
+	}
 
-	
 	// create a map of strings to a map of runes to a slice of strings
 	transitions := make(map[string]map[rune][]string)
+	classTransitions := make(map[string][]ClassTransition)
 	// loop through the transitions and set the transitions of each state
 	for state, transition := range jsonInput.Transitions {
 		// create a map of runes to a slice of strings
 		t := make(map[rune][]string)
 		// loop through the transitions of each state
-		// fmt.Println(transition)
-		// fmt.Println(transition)
 		for k, m := range transition {
-			fmt.Println(k,[]rune(k))
-			if len(k) == 1 {
-				// loop through the target states of each symbol
-				for _, targetState := range m {
-					t[rune(k[0])] = append(t[rune(k[0])], targetState) // append the target state to the transitions of the state
-				}
-			}else {
-				fmt.Printf("Skipping key '%s' because it's not a single character\n", k)
+			var symbol rune
+			if jsonInput.IsEpsilon(k) {
+				symbol = epsilon // every epsilon spelling shares the same rune
+			} else if class, err := jsonInput.ParseLabel(k, tokens); err != nil {
+				fmt.Printf("Skipping key '%s': %v\n", k, err)
+				continue
+			} else if r, ok := class.Rune(); ok {
+				symbol = r
+			} else {
+				classTransitions[state] = append(classTransitions[state], ClassTransition{Class: class, Targets: append([]string{}, m...)})
+				continue
+			}
+			// loop through the target states of each symbol
+			for _, targetState := range m {
+				t[symbol] = append(t[symbol], targetState) // append the target state to the transitions of the state
 			}
-			transitions[state] = t
 		}
+		transitions[state] = t
+		// sort the class transitions so the NFA does not depend on the map order
+		edges := classTransitions[state]
+		sort.Slice(edges, func(i, j int) bool { return edges[i].Class.Min() < edges[j].Class.Min() })
 	}
-	// create a slice of runes
+	// create a slice of runes and one of character classes
 	symbols := make([]rune, 0)
+	classes := make([]charclass.Class, 0)
 	for _, c := range jsonInput.Symbols {
-		if len(c) == 1 {
-			symbols = append(symbols, rune(c[0])) // append the rune to the symbols slice
+		if jsonInput.IsEpsilon(c) {
+			continue // epsilon is not an input symbol
+		}
+		if class, err := jsonInput.ParseLabel(c, tokens); err != nil {
+			fmt.Printf("Skipping key '%s': %v\n", c, err)
+		} else if r, ok := class.Rune(); ok {
+			symbols = append(symbols, r) // append the rune to the symbols slice
 		} else {
-			fmt.Printf("Skipping key '%s' because it's not a single sqs character\n", c) // i think this is a bug //throw "Skipping key 'ε' because it's not a single character" error
-			fmt.Printf("Skipping key '%s' because it's not a single sqs character\n", c) // i think this is a bug //throw "Skipping key 'ε' because it's not a single character" error
+			classes = append(classes, class)
 		}
 	}
 	// create a NFA struct
-	nfa := &NFA{
-		States:       jsonInput.States,
-		Symbols:      symbols,
-		Transitions:  transitions,
-		StartState:   constructNodes(jsonInput),
-		AcceptStates: jsonInput.AcceptStates,
-	}
+	nfa := NewSymbolic(jsonInput.States, symbols, classes, transitions, classTransitions, jsonInput.StartState, jsonInput.AcceptStates)
+	nfa.Epsilon = epsilon
+	nfa.Tokens = tokens
 
 	return nfa
 
 }
+
+/**
+ * This function converts the NFA back to the JSON representation read by utils.ReadJsonNfa
+ * Epsilon moves are keyed by the epsilon symbol, which is always written out so no other spelling is taken for it
+ * @return A NFiniteAutomata struct that represents the NFA
+ */
+func (nfa *NFA) ToNFiniteAutomata() utils.NFiniteAutomata {
+	symbols := make([]string, 0, len(nfa.Symbols)+len(nfa.Classes))
+	for _, symbol := range nfa.Symbols {
+		symbols = append(symbols, nfa.Tokens.Symbol(symbol))
+	}
+	for _, class := range nfa.Classes {
+		symbols = append(symbols, class.String())
+	}
+
+	transitions := make(map[string]map[string][]string, len(nfa.Transitions))
+	row := func(state string) map[string][]string {
+		if transitions[state] == nil {
+			transitions[state] = make(map[string][]string)
+		}
+		return transitions[state]
+	}
+	for state, transition := range nfa.Transitions {
+		t := row(state)
+		for symbol, targets := range transition {
+			key := nfa.Tokens.Symbol(symbol)
+			t[key] = append(t[key], targets...)
+		}
+	}
+	for state, edges := range nfa.ClassTransitions {
+		t := row(state)
+		for _, edge := range edges {
+			key := edge.Class.String()
+			t[key] = append(t[key], edge.Targets...)
+		}
+	}
+
+	alphabet := ""
+	if nfa.HasTokenAlphabet() {
+		alphabet = utils.AlphabetTokens
+	}
+	return utils.NFiniteAutomata{
+		States:       append([]string{}, nfa.States...),
+		Symbols:      symbols,
+		StartState:   nfa.StartState.StateName,
+		AcceptStates: append([]string{}, nfa.AcceptStates...),
+		Transitions:  transitions,
+		Epsilon:      nfa.Tokens.Symbol(nfa.epsilon()),
+		Alphabet:     alphabet,
+	}
+}
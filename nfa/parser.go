@@ -76,7 +76,7 @@ func (nfa *NFA) ParseTree(input string) *StateNode {
 		queue.Remove(queue.Front())
 
 		//base case: when the current state is nil or there is no transition for the current symbol
-		if currentState == nil || nfa.Transitions[currentState.StateName][currentSymbol] == nil {
+		if currentState == nil || len(nfa.next(currentState.StateName, currentSymbol)) == 0 {
 			return nil
 		}
 
@@ -88,14 +88,14 @@ func (nfa *NFA) ParseTree(input string) *StateNode {
 		}
 
 		// Get the slice of possible next states for the current symbol
-		for _, nextState := range nfa.Transitions[currentState.StateName][currentSymbol] {
+		for _, nextState := range nfa.next(currentState.StateName, currentSymbol) {
 			childNode := buildTree(nfa.getNode(nextState))
 			node.Transitions[currentSymbol] = append(node.Transitions[currentSymbol], childNode)
 		}
 		// handle epsilon transitions
-		for _, nextState := range nfa.Transitions[currentState.StateName]['ε'] {
+		for _, nextState := range nfa.Transitions[currentState.StateName][nfa.epsilon()] {
 			childNode := buildTree(nfa.getNode(nextState))
-			node.Transitions['ε'] = append(node.Transitions['ε'], childNode)
+			node.Transitions[nfa.epsilon()] = append(node.Transitions[nfa.epsilon()], childNode)
 		}
 		// Return the node
 		return node
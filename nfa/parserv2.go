@@ -1,9 +1,5 @@
 package nfa
 
-import (
-	"fmt"
-)
-
 func (nfa *NFA) ValidateStringDac(input []rune) bool {
 	//queue := list.New()
 	//
@@ -16,7 +12,7 @@ func (nfa *NFA) ValidateStringDac(input []rune) bool {
 	for _, symbol := range input {
 		queue.Enqueue(symbol)
 	}
-	return parserDac(nfa.StartState, queue)
+	return parserDac(nfa.StartState, queue, nfa.epsilon())
 }
 
 //func parserDac(startState *StateNode, chars list.List) bool {
@@ -71,29 +67,26 @@ func (nfa *NFA) ValidateStringDac(input []rune) bool {
 //    return false
 //}
 
-func parserDac(startState *StateNode, chars *Queue) bool {
+func parserDac(startState *StateNode, chars *Queue, epsilon rune) bool {
 	if chars.Size() == 0 {
 		return startState.IsAccepting
 	}
 
 	nextChar := chars.Dequeue().(rune)
-	fmt.Println(startState.StateName, nextChar, chars.Size())
 
-	// Check for transitions with the current character
-	if nextStates, ok := startState.Transitions[nextChar]; ok {
-		for _, nextState := range nextStates {
-			newChars := chars.Copy()
-			if parserDac(nextState, newChars) {
-				return true
-			}
+	// Check for transitions with the current character, including character classes
+	for _, nextState := range startState.next(nextChar, epsilon) {
+		newChars := chars.Copy()
+		if parserDac(nextState, newChars, epsilon) {
+			return true
 		}
 	}
 
 	// Check for epsilon transitions (empty transitions)
-	if epsilonTransitions, ok := startState.Transitions['_']; ok {
+	if epsilonTransitions, ok := startState.Transitions[epsilon]; ok {
 		for _, nextState := range epsilonTransitions {
 			newChars := chars.Copy()
-			if parserDac(nextState, newChars) {
+			if parserDac(nextState, newChars, epsilon) {
 				return true
 			}
 		}
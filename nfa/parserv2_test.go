@@ -64,5 +64,8 @@ func TestValidateStringDac(t *testing.T) {
 		if result != tc.expected {
 			t.Errorf("ValidateStringDac(%q) = %v; want %v", string(tc.input), result, tc.expected)
 		}
+		if result := tc.nfa.ValidateStringSet(tc.input); result != tc.expected {
+			t.Errorf("ValidateStringSet(%q) = %v; want %v", string(tc.input), result, tc.expected)
+		}
 	}
 }
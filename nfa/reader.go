@@ -0,0 +1,20 @@
+package nfa
+
+import (
+	"io"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+)
+
+/**
+ * This function runs the NFA over the runes of r without reading the whole input into memory
+ * @param r: The input, decoded as UTF-8
+ * @param options: Whether to stop as soon as the NFA cannot accept anymore
+ * @return The verdict with the bytes and runes consumed, see dfa.MatchReader, or dfa.ErrTokenAlphabet for an NFA over tokens
+ */
+func (nfa *NFA) MatchReader(r io.Reader, options dfa.MatchOptions) (dfa.MatchResult, error) {
+	if nfa.Tokens != nil {
+		return dfa.MatchResult{}, dfa.ErrTokenAlphabet
+	}
+	return dfa.MatchReader(nfa.NewMatcher(), r, options)
+}
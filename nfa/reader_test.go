@@ -0,0 +1,48 @@
+package nfa
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestMatchReader(t *testing.T) {
+	// strings over a and b whose second to last symbol is a
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q0", "q1"}, "b": {"q0"}},
+			"q1": {"a": {"q2"}, "b": {"q2"}},
+		},
+	})
+
+	input := strings.Repeat("ab", 100000) + "ab"
+	result, err := nfa.MatchReader(strings.NewReader(input), dfa.MatchOptions{StopWhenDead: true})
+	if err != nil || !result.Accepted || result.Runes != int64(len(input)) {
+		t.Errorf("MatchReader() = %+v, %v; want %d runes accepted", result, err, len(input))
+	}
+	result, err = nfa.MatchReader(strings.NewReader("abcab"), dfa.MatchOptions{StopWhenDead: true})
+	if err != nil || result.Accepted || !result.Dead || result.Runes != 3 {
+		t.Errorf("MatchReader(abcab) = %+v, %v; want dead after 3 runes", result, err)
+	}
+}
+
+func TestMatchReaderTokens(t *testing.T) {
+	nfa := Constructor(utils.NFiniteAutomata{
+		Alphabet:     utils.AlphabetTokens,
+		States:       []string{"q0"},
+		Symbols:      []string{"GET"},
+		StartState:   "q0",
+		AcceptStates: []string{"q0"},
+		Transitions:  map[string]map[string][]string{"q0": {"GET": {"q0"}}},
+	})
+	if _, err := nfa.MatchReader(strings.NewReader("GET"), dfa.MatchOptions{}); !errors.Is(err, dfa.ErrTokenAlphabet) {
+		t.Errorf("MatchReader() error = %v; want ErrTokenAlphabet", err)
+	}
+}
@@ -0,0 +1,42 @@
+package nfa
+
+/**
+ * This function finds the shortest string accepted from a state, following epsilon moves for free
+ * @param state: The state to start from, the start state if empty
+ * @return The string and true, or false if no accepting state can be reached or the state does not exist
+ */
+func (nfa *NFA) ShortestAccepted(state string) ([]rune, bool) {
+	from, exists := nfa.origin(state)
+	if !exists {
+		return nil, false
+	}
+	accepting := nfa.acceptingStateSet()
+	word, _, found := nfa.graph().ShortestWord([]string{from}, func(s string) bool { return accepting[s] })
+	return word, found
+}
+
+/**
+ * This function finds the shortest string over the input symbols rejected from a state
+ * The NFA is determinized from that state since a string is only rejected if every path rejects it
+ * @param state: The state to start from, the start state if empty
+ * @return The string and true, or false if every string is accepted or the state does not exist
+ */
+func (nfa *NFA) ShortestRejected(state string) ([]rune, bool) {
+	from, exists := nfa.origin(state)
+	if !exists {
+		return nil, false
+	}
+	moved := NewSymbolic(nfa.States, nfa.Symbols, nfa.Classes, nfa.Transitions, nfa.ClassTransitions, from, nfa.AcceptStates)
+	moved.Epsilon = nfa.epsilon()
+	moved.Tokens = nfa.Tokens
+	return moved.ToDFA().ShortestRejected("")
+}
+
+// origin returns the state a query starts from, the start state if state is empty
+func (nfa *NFA) origin(state string) (string, bool) {
+	if state == "" {
+		return nfa.StartState.StateName, true
+	}
+	_, exists := nfa.stateOrder()[state]
+	return state, exists
+}
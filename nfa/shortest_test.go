@@ -0,0 +1,39 @@
+package nfa
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestShortest(t *testing.T) {
+	// strings over a and b whose second to last symbol is a
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q0", "q1"}, "b": {"q0"}},
+			"q1": {"a": {"q2"}, "b": {"q2"}},
+		},
+	})
+
+	tests := []struct {
+		state    string
+		accepted string
+		rejected string
+	}{
+		{"", "aa", ""},
+		{"q1", "a", ""},
+		{"q2", "", "a"},
+	}
+	for _, test := range tests {
+		if word, found := nfa.ShortestAccepted(test.state); !found || string(word) != test.accepted {
+			t.Errorf("ShortestAccepted(%q) = %q, %v; want %q", test.state, string(word), found, test.accepted)
+		}
+		if word, found := nfa.ShortestRejected(test.state); !found || string(word) != test.rejected {
+			t.Errorf("ShortestRejected(%q) = %q, %v; want %q", test.state, string(word), found, test.rejected)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package nfa
+
+/**
+ * This function validates the input string by simulating the NFA on the set of active states
+ * Instead of trying every branch like ValidateStringDac, all the states the NFA can be in are
+ * advanced together one symbol at a time, so it runs in O(n·m) for n symbols and m states
+ * and terminates on epsilon cycles
+ * @param input: The input symbols
+ * @return A boolean that indicates if the NFA accepts the input
+ */
+func (nfa *NFA) ValidateStringSet(input []rune) bool {
+	if nfa.StartState == nil {
+		return false
+	}
+	epsilon := nfa.epsilon()
+	active := nodeClosure([]*StateNode{nfa.StartState}, epsilon) // the states the NFA is in before reading any symbol
+
+	for _, symbol := range input {
+		active = advance(active, symbol, epsilon)
+		if len(active) == 0 {
+			return false // no branch can continue, the rest of the input cannot be read
+		}
+	}
+	return anyAccepting(active)
+}
+
+/**
+ * This function advances every active state on the symbol
+ * @param active: The states the NFA is in, closed under epsilon moves
+ * @param symbol: The symbol to read
+ * @param epsilon: The rune that labels epsilon moves
+ * @return The epsilon closure of the targets, empty if no state can read the symbol
+ */
+func advance(active []*StateNode, symbol rune, epsilon rune) []*StateNode {
+	next := make([]*StateNode, 0, len(active))
+	seen := map[*StateNode]bool{}
+	for _, state := range active {
+		for _, target := range state.next(symbol, epsilon) {
+			if !seen[target] {
+				seen[target] = true
+				next = append(next, target)
+			}
+		}
+	}
+	if len(next) == 0 {
+		return next
+	}
+	return nodeClosure(next, epsilon)
+}
+
+// anyAccepting reports whether one of the states is accepting
+func anyAccepting(states []*StateNode) bool {
+	for _, state := range states {
+		if state.IsAccepting {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * This function is ValidateStringSet for NFAs over a token alphabet
+ * @param tokens: The input tokens, a token the NFA does not know is rejected
+ * @return A boolean that indicates if the NFA accepts the input
+ */
+func (nfa *NFA) ValidateTokens(tokens []string) bool {
+	symbols, known := nfa.Tokens.Runes(tokens)
+	if !known {
+		return false
+	}
+	return nfa.ValidateStringSet(symbols)
+}
+
+// HasTokenAlphabet reports whether the symbols of the NFA are tokens
+func (nfa *NFA) HasTokenAlphabet() bool {
+	return nfa.Tokens != nil
+}
+
+/**
+ * This function returns the given nodes together with every node reachable through epsilon moves
+ * It works on the node graph so it also handles NFAs built without a transition table
+ * @param nodes: The nodes to start from
+ * @param epsilon: The rune that labels epsilon moves
+ * @return The epsilon closure of the nodes
+ */
+func nodeClosure(nodes []*StateNode, epsilon rune) []*StateNode {
+	seen := make(map[*StateNode]bool, len(nodes))
+	result := make([]*StateNode, 0, len(nodes))
+	for _, node := range nodes {
+		seen[node] = true
+	}
+	stack := append([]*StateNode{}, nodes...)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		result = append(result, node)
+		for _, next := range node.Transitions[epsilon] {
+			if !seen[next] { // visited nodes are skipped so epsilon cycles terminate
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return result
+}
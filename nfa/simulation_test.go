@@ -0,0 +1,59 @@
+package nfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestValidateStringSet(t *testing.T) {
+	// q0 and q1 form an epsilon cycle, which makes ValidateStringDac recurse forever
+	cyclic := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"_": {"q1"}, "a": {"q0"}},
+			"q1": {"_": {"q0"}, "b": {"q2"}},
+		},
+	})
+
+	// every state loops on a and may move on to the next one, so there are
+	// exponentially many paths for a long run of a's followed by a b
+	ambiguous := Constructor(utils.NFiniteAutomata{
+		States:       []string{"s0", "s1", "s2", "s3", "s4"},
+		Symbols:      []string{"a"},
+		StartState:   "s0",
+		AcceptStates: []string{"s4"},
+		Transitions: map[string]map[string][]string{
+			"s0": {"a": {"s0", "s1"}},
+			"s1": {"a": {"s1", "s2"}},
+			"s2": {"a": {"s2", "s3"}},
+			"s3": {"a": {"s3", "s4"}},
+		},
+	})
+
+	testCases := []struct {
+		nfa      *NFA
+		input    string
+		expected bool
+	}{
+		{nfa: cyclic, input: "b", expected: true},
+		{nfa: cyclic, input: "aab", expected: true},
+		{nfa: cyclic, input: "", expected: false},
+		{nfa: cyclic, input: "a", expected: false},
+		{nfa: cyclic, input: "ba", expected: false},
+		{nfa: ambiguous, input: "aaaa", expected: true},
+		{nfa: ambiguous, input: "aaa", expected: false},
+		{nfa: ambiguous, input: strings.Repeat("a", 5000), expected: true},
+		{nfa: ambiguous, input: strings.Repeat("a", 5000) + "b", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.nfa.ValidateStringSet([]rune(tc.input)); result != tc.expected {
+			t.Errorf("ValidateStringSet(%.10q) = %v; want %v", tc.input, result, tc.expected)
+		}
+	}
+}
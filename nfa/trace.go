@@ -0,0 +1,39 @@
+package nfa
+
+import "github.com/dekuu5/FiniteStateMachine/utils"
+
+/**
+ * This function runs the NFA on the input like ValidateStringSet and records the active states
+ * after every symbol, in the order of States
+ * A rejection names the symbol no active state can read or the non-accepting states the run ended in
+ * @param input: The input symbols
+ * @return The trace of the run
+ */
+func (nfa *NFA) Trace(input []rune) *utils.Trace {
+	if nfa.StartState == nil {
+		return utils.NewTrace(nfa.Tokens, input, nil).End(false)
+	}
+	epsilon := nfa.epsilon()
+	order := nfa.stateOrder()
+	active := nodeClosure([]*StateNode{nfa.StartState}, epsilon)
+	trace := utils.NewTrace(nfa.Tokens, input, stateNames(active, order))
+	for position, symbol := range input {
+		next := advance(active, symbol, epsilon)
+		if len(next) == 0 {
+			return trace.Stuck(position, symbol, stateNames(active, order))
+		}
+		active = next
+		trace.Enter(position, symbol, stateNames(active, order))
+	}
+	return trace.End(anyAccepting(active))
+}
+
+// stateNames returns the names of the nodes in the order of States
+func stateNames(nodes []*StateNode, order map[string]int) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.StateName)
+	}
+	sortStates(names, order)
+	return names
+}
@@ -0,0 +1,43 @@
+package nfa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestTrace(t *testing.T) {
+	// strings over a and b whose second to last symbol is a
+	nfa := Constructor(utils.NFiniteAutomata{
+		States:       []string{"q0", "q1", "q2"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "q0",
+		AcceptStates: []string{"q2"},
+		Transitions: map[string]map[string][]string{
+			"q0": {"a": {"q0", "q1"}, "b": {"q0"}},
+			"q1": {"a": {"q2"}, "b": {"q2"}},
+		},
+	})
+
+	trace := nfa.Trace([]rune("ab"))
+	want := [][]string{{"q0"}, {"q0", "q1"}, {"q0", "q2"}}
+	for i, step := range trace.Steps {
+		if i >= len(want) || !reflect.DeepEqual(step.States, want[i]) {
+			t.Errorf("Trace(ab) step %d = %+v; want states %v", i, step, want)
+		}
+	}
+	if !trace.Accepted || len(trace.Steps) != len(want) {
+		t.Errorf("Trace(ab) = %+v; want accepted after %d steps", trace, len(want))
+	}
+
+	trace = nfa.Trace([]rune("ba"))
+	if trace.Accepted || trace.Rejection.Reason != utils.RejectNonAccepting || !reflect.DeepEqual(trace.Rejection.States, []string{"q0", "q1"}) {
+		t.Errorf("Trace(ba) = %+v, %+v; want a rejection in {q0, q1}", trace, trace.Rejection)
+	}
+
+	trace = nfa.Trace([]rune("ac"))
+	if trace.Accepted || trace.Rejection.Reason != utils.RejectMissingTransition || trace.Rejection.Position != 1 || trace.Rejection.Symbol != "c" {
+		t.Errorf("Trace(ac) = %+v, %+v; want no transition on c at position 1", trace, trace.Rejection)
+	}
+}
@@ -4,9 +4,13 @@ package nfa
  * description: the file contains the functions that validate the given NFA based on the following rules:
  * 1. The set of states must not be empty.
  * 2. The start state must be in the set of states.
- * 3. The set of input symbols must not be empty.
- * 4. The set of accept states must not be empty and must be a subset of the set of states.
+ * 3. The set of input symbols must not be empty and every symbol must be a single Unicode character
+ *    or a character class such as [a-z], or a non-empty token when the alphabet is "tokens".
+ * 4. The set of accept states must be a subset of the set of states, an empty set is only a warning
+ *    since the automaton then accepts nothing.
  * 5. Each state may have transitions for each input symbol, and the next states must be in the set of states or no transitions at all.
+ *    Epsilon moves are allowed without declaring the epsilon symbol. A character class is allowed
+ *    as input when all of its runes are declared inputs.
  * @param nfa: A NFA struct that represents the NFA
  * @return A boolean that indicates if the NFA is valid
  */
@@ -14,6 +18,7 @@ package nfa
 import (
 	"log"
 
+	"github.com/dekuu5/FiniteStateMachine/charclass"
 	"github.com/dekuu5/FiniteStateMachine/utils"
 )
 
@@ -21,77 +26,156 @@ type NFiniteAutomata = utils.NFiniteAutomata
 
 /**
  * This function validates the given NFA based on mentioned rules above
+ * Every problem found is logged
  * @param nfa: A NFiniteAutomata struct that represents the NFA
  * @return A boolean that indicates if the NFA is valid
  */
 func ValidateNfa(nfa utils.NFiniteAutomata) bool {
-	return validateStates(nfa) && // check if the set of states is not empty
-		validateStartState(nfa) && // check if the start state is in the set of states
-		validateSymbols(nfa) && // check if the set of input symbols is not empty
-		validateAcceptStates(nfa) && // check if the set of accept states is not empty and is a subset of the set of states
-		validateTransitions(nfa) // check if the transitions are valid based on the set of states and input symbols
+	report := ValidateNfaReport(nfa)
+	for _, issue := range report.Issues {
+		log.Println(issue.Message)
+	}
+	return report.Valid()
 }
 
-func validateStates(nfa NFiniteAutomata) bool {
+/**
+ * This function runs every check on the given NFA instead of stopping at the first failure
+ * @param nfa: A NFiniteAutomata struct that represents the NFA
+ * @return A report listing all the problems found
+ */
+func ValidateNfaReport(nfa utils.NFiniteAutomata) *utils.Report {
+	report := &utils.Report{}
+	validateStates(nfa, report)       // check if the set of states is not empty
+	validateStartState(nfa, report)   // check if the start state is in the set of states
+	validateSymbols(nfa, report)      // check if the set of input symbols is not empty
+	validateAcceptStates(nfa, report) // check if the set of accept states is a subset of the set of states, warn if it is empty
+	validateTransitions(nfa, report)  // check if the transitions are valid based on the set of states and input symbols
+	validateStateUsage(nfa, report)   // warn about the states that are unreachable or dead
+	return report
+}
+
+func validateStates(nfa NFiniteAutomata, report *utils.Report) {
 	if len(nfa.States) == 0 {
-		log.Println("Set of states is empty")
-		return false
+		report.Add(utils.CodeEmptyStates, utils.SeverityError, "", "", "Set of states is empty")
 	}
-	return true
 }
 
-func validateStartState(nfa NFiniteAutomata) bool {
-	for _, state := range nfa.States {
-		if state == nfa.StartState {
-			return true
-		}
+func validateStartState(nfa NFiniteAutomata, report *utils.Report) {
+	if !stateExists(nfa.States, nfa.StartState) {
+		report.Add(utils.CodeUnknownStartState, utils.SeverityError, nfa.StartState, "", "Start state is not in the set of states")
 	}
-	log.Println("Start state is not in the set of states")
-	return false
 }
 
-func validateSymbols(nfa NFiniteAutomata) bool {
+func validateSymbols(nfa NFiniteAutomata, report *utils.Report) {
 	if len(nfa.Symbols) == 0 {
-		log.Println("Set of inputs is empty")
-		return false
+		report.Add(utils.CodeEmptySymbols, utils.SeverityError, "", "", "Set of inputs is empty")
+	}
+	if !utils.ValidAlphabet(nfa.Alphabet) {
+		report.Add(utils.CodeInvalidAlphabet, utils.SeverityError, "", "", "Alphabet %q is neither %q nor %q", nfa.Alphabet, utils.AlphabetRunes, utils.AlphabetTokens)
+		return
+	}
+	for _, symbol := range nfa.Symbols {
+		if utils.ValidSymbol(nfa.Alphabet, symbol) || nfa.IsEpsilon(symbol) {
+			continue
+		}
+		switch {
+		case nfa.Alphabet == utils.AlphabetTokens:
+			report.Add(utils.CodeInvalidSymbol, utils.SeverityError, "", symbol, "Input tokens must not be empty")
+		case utils.IsClassLabel(nfa.Alphabet, symbol): // a class expression, it is only reported when it does not parse
+			if _, err := charclass.Parse(symbol); err != nil {
+				report.Add(utils.CodeInvalidClass, utils.SeverityError, "", symbol, "Input %q is not a valid character class: %v", symbol, err)
+			}
+		default:
+			report.Add(utils.CodeInvalidSymbol, utils.SeverityError, "", symbol, "Input %q is not a single Unicode character or a character class", symbol)
+		}
+	}
+	if nfa.Epsilon != "" && !utils.ValidSymbol(nfa.Alphabet, nfa.Epsilon) {
+		report.Add(utils.CodeInvalidSymbol, utils.SeverityError, "", nfa.Epsilon, "Epsilon %q is not a valid symbol", nfa.Epsilon)
 	}
-	return true
 }
 
-func validateAcceptStates(nfa NFiniteAutomata) bool {
+func validateAcceptStates(nfa NFiniteAutomata, report *utils.Report) {
 	if len(nfa.AcceptStates) == 0 {
-		log.Println("Set of accepted states is empty")
-		return false
+		report.Add(utils.CodeEmptyAcceptStates, utils.SeverityWarning, "", "", "Set of accepted states is empty, the automaton accepts nothing")
 	}
 	for _, acceptState := range nfa.AcceptStates {
 		if !stateExists(nfa.States, acceptState) {
-			log.Printf("Accepted state %s is not in the set of states", acceptState)
-			return false
+			report.Add(utils.CodeUnknownAcceptState, utils.SeverityError, acceptState, "", "Accepted state %s is not in the set of states", acceptState)
 		}
 	}
-	return true
 }
 
-func validateTransitions(nfa NFiniteAutomata) bool {
-	for state, transitions := range nfa.Transitions {
+func validateTransitions(nfa NFiniteAutomata, report *utils.Report) {
+	alphabet := alphabetClass(nfa)
+	for _, state := range utils.SortedKeys(nfa.Transitions) { // sorted so the report is stable
+		transitions := nfa.Transitions[state]
 		if !stateExists(nfa.States, state) {
-			log.Printf("State %s in transition table is not in the set of states", state)
-			return false
+			report.Add(utils.CodeUnknownSourceState, utils.SeverityError, state, "", "State %s in transition table is not in the set of states", state)
 		}
-		for input, nextStates := range transitions {
-			if !symbolExists(nfa.Symbols, input) {
-				log.Printf("Input %s in transition table for state %s is not in the set of inputs", input, state)
-				return false
+		for _, input := range utils.SortedKeys(transitions) {
+			if !nfa.IsEpsilon(input) && !symbolExists(nfa.Symbols, input) { // epsilon moves need not be declared as symbols
+				validateInput(nfa, alphabet, state, input, report)
 			}
-			for _, nextState := range nextStates {
+			for _, nextState := range transitions[input] {
 				if !stateExists(nfa.States, nextState) {
-					log.Printf("Next state %s in transition table for state %s is not in the set of states", string(nextState), state)
-					return false
+					report.Add(utils.CodeUnknownTargetState, utils.SeverityError, state, input, "Next state %s in transition table for state %s is not in the set of states", nextState, state)
 				}
 			}
 		}
 	}
-	return true
+}
+
+/**
+ * This function warns about the states that cannot be reached from the start state
+ * and the ones that cannot reach an accepting state
+ * Nothing is reported without a valid start state since every state would be unreachable
+ */
+func validateStateUsage(nfa NFiniteAutomata, report *utils.Report) {
+	if !stateExists(nfa.States, nfa.StartState) {
+		return
+	}
+	successors := make(map[string][]string, len(nfa.Transitions))
+	for state, transitions := range nfa.Transitions {
+		for _, targets := range transitions {
+			successors[state] = append(successors[state], targets...)
+		}
+	}
+	utils.Analyze(nfa.States, successors, nfa.StartState, nfa.AcceptStates).AddWarnings(report)
+}
+
+/**
+ * This function checks a transition key that is not a declared input symbol
+ * It must be a valid character class whose runes are declared, or a character of a declared class
+ * @param alphabet: The runes of the declared inputs
+ */
+func validateInput(nfa NFiniteAutomata, alphabet charclass.Class, state, input string, report *utils.Report) {
+	if utils.IsClassLabel(nfa.Alphabet, input) {
+		class, err := charclass.Parse(input)
+		if err != nil {
+			report.Add(utils.CodeInvalidClass, utils.SeverityError, state, input, "Input %s in transition table for state %s is not a valid character class: %v", input, state, err)
+			return
+		}
+		if class.Subtract(alphabet).IsEmpty() {
+			return
+		}
+	} else if r, ok := utils.SymbolRune(input); ok && nfa.Alphabet != utils.AlphabetTokens && alphabet.Contains(r) {
+		return
+	}
+	report.Add(utils.CodeUnknownSymbol, utils.SeverityError, state, input, "Input %s in transition table for state %s is not in the set of inputs", input, state)
+}
+
+// alphabetClass returns the runes of the declared inputs, it is empty for tokens
+func alphabetClass(nfa NFiniteAutomata) charclass.Class {
+	alphabet := charclass.Class{}
+	if nfa.Alphabet == utils.AlphabetTokens {
+		return alphabet
+	}
+	for _, symbol := range nfa.Symbols {
+		if class, ok := utils.LabelClass(symbol); ok && !nfa.IsEpsilon(symbol) {
+			alphabet = alphabet.Union(class)
+		}
+	}
+	return alphabet
 }
 
 func stateExists(states []string, state string) bool {
@@ -0,0 +1,192 @@
+package regex
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+	"github.com/dekuu5/FiniteStateMachine/nfa"
+)
+
+/**
+ * This function compiles the pattern into a NFA using the Thompson construction
+ * Epsilon moves use nfa.EpsilonSymbol like the rest of the nfa package; if the pattern
+ * itself uses that symbol another rune that does not occur in the pattern is chosen
+ * A class of more than one rune becomes a single character class transition
+ * @param pattern: The regular expression, see parser.go for the syntax
+ * @return The compiled NFA or a *SyntaxError
+ */
+func Compile(pattern string) (*nfa.NFA, error) {
+	root, err := parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{
+		transitions:      make(map[string]map[rune][]string),
+		classTransitions: make(map[string][]nfa.ClassTransition),
+		used:             make(map[rune]bool),
+		classes:          make(map[string]charclass.Class),
+	}
+	collectSymbols(root, b.used, b.classes)
+	b.epsilon = pickEpsilon(b.used)
+
+	start, end := b.build(root)
+
+	symbols := make([]rune, 0, len(b.used))
+	for c := range b.used {
+		symbols = append(symbols, c)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+	classes := make([]charclass.Class, 0, len(b.classes))
+	for _, class := range b.classes {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].String() < classes[j].String() })
+
+	compiled := nfa.NewSymbolic(b.states, symbols, classes, b.transitions, b.classTransitions, start, []string{end})
+	compiled.Epsilon = b.epsilon
+	return compiled, nil
+}
+
+// MustCompile is like Compile but panics if the pattern cannot be parsed
+func MustCompile(pattern string) *nfa.NFA {
+	compiled, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// builder holds the NFA under construction
+type builder struct {
+	states           []string
+	transitions      map[string]map[rune][]string
+	classTransitions map[string][]nfa.ClassTransition
+	used             map[rune]bool              // the single rune input symbols of the pattern
+	classes          map[string]charclass.Class // the classes of the pattern by their rendering
+	epsilon          rune
+}
+
+// newState adds a state named q0, q1, ...
+func (b *builder) newState() string {
+	state := fmt.Sprintf("q%d", len(b.states))
+	b.states = append(b.states, state)
+	b.transitions[state] = make(map[rune][]string)
+	return state
+}
+
+func (b *builder) addEdge(from string, symbol rune, to string) {
+	b.transitions[from][symbol] = append(b.transitions[from][symbol], to)
+}
+
+/**
+ * This function builds the fragment of a syntax tree node
+ * Every fragment has a single start and a single accepting end state
+ * @param n: The node to build
+ * @return The start and end state of the fragment
+ */
+func (b *builder) build(n node) (string, string) {
+	switch n := n.(type) {
+	case emptyNode:
+		start, end := b.newState(), b.newState()
+		b.addEdge(start, b.epsilon, end)
+		return start, end
+	case symbolNode:
+		start, end := b.newState(), b.newState()
+		if c, ok := n.class.Rune(); ok {
+			b.addEdge(start, c, end)
+		} else {
+			b.classTransitions[start] = append(b.classTransitions[start], nfa.ClassTransition{Class: n.class, Targets: []string{end}})
+		}
+		return start, end
+	case concatNode:
+		start, end := b.build(n.parts[0])
+		for _, part := range n.parts[1:] {
+			partStart, partEnd := b.build(part)
+			b.addEdge(end, b.epsilon, partStart)
+			end = partEnd
+		}
+		return start, end
+	case alterNode:
+		start := b.newState()
+		ends := make([]string, 0, len(n.parts))
+		for _, part := range n.parts {
+			partStart, partEnd := b.build(part)
+			b.addEdge(start, b.epsilon, partStart)
+			ends = append(ends, partEnd)
+		}
+		end := b.newState()
+		for _, partEnd := range ends {
+			b.addEdge(partEnd, b.epsilon, end)
+		}
+		return start, end
+	case starNode:
+		start := b.newState()
+		innerStart, innerEnd := b.build(n.inner)
+		end := b.newState()
+		b.addEdge(start, b.epsilon, innerStart)
+		b.addEdge(start, b.epsilon, end)
+		b.addEdge(innerEnd, b.epsilon, innerStart)
+		b.addEdge(innerEnd, b.epsilon, end)
+		return start, end
+	case plusNode:
+		start := b.newState()
+		innerStart, innerEnd := b.build(n.inner)
+		end := b.newState()
+		b.addEdge(start, b.epsilon, innerStart)
+		b.addEdge(innerEnd, b.epsilon, innerStart)
+		b.addEdge(innerEnd, b.epsilon, end)
+		return start, end
+	case optNode:
+		start := b.newState()
+		innerStart, innerEnd := b.build(n.inner)
+		end := b.newState()
+		b.addEdge(start, b.epsilon, innerStart)
+		b.addEdge(start, b.epsilon, end)
+		b.addEdge(innerEnd, b.epsilon, end)
+		return start, end
+	}
+	panic(fmt.Sprintf("regex: unknown node %T", n))
+}
+
+// collectSymbols adds every single rune of the tree to used and every larger class to classes
+func collectSymbols(n node, used map[rune]bool, classes map[string]charclass.Class) {
+	switch n := n.(type) {
+	case symbolNode:
+		if c, ok := n.class.Rune(); ok {
+			used[c] = true
+		} else {
+			classes[n.class.String()] = n.class
+		}
+	case concatNode:
+		for _, part := range n.parts {
+			collectSymbols(part, used, classes)
+		}
+	case alterNode:
+		for _, part := range n.parts {
+			collectSymbols(part, used, classes)
+		}
+	case starNode:
+		collectSymbols(n.inner, used, classes)
+	case plusNode:
+		collectSymbols(n.inner, used, classes)
+	case optNode:
+		collectSymbols(n.inner, used, classes)
+	}
+}
+
+// pickEpsilon returns nfa.EpsilonSymbol unless the pattern uses it as a single rune input symbol,
+// classes do not matter since the epsilon rune read as input only follows class transitions
+func pickEpsilon(used map[rune]bool) rune {
+	for _, candidate := range []rune{nfa.EpsilonSymbol, 'ε', '\uE000'} {
+		if !used[candidate] {
+			return candidate
+		}
+	}
+	for candidate := rune(0xE001); ; candidate++ { // the private use area is unlikely to show up in real input
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
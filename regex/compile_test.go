@@ -0,0 +1,74 @@
+package regex
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		inputs  []string
+	}{
+		{pattern: "ab", inputs: []string{"", "a", "ab", "abb"}},
+		{pattern: "a|b|", inputs: []string{"", "a", "b", "ab"}},
+		{pattern: "(ab)*", inputs: []string{"", "ab", "abab", "aba", "ba"}},
+		{pattern: "a+b?", inputs: []string{"", "a", "aaab", "b", "abb"}},
+		{pattern: "(a|b)*abb", inputs: []string{"abb", "aabb", "babb", "ab", "abba"}},
+		{pattern: "[a-c]x[^a-y]", inputs: []string{"axz", "bx_", "cxa", "dxz", "ax"}},
+		{pattern: "\\d+\\.\\d*", inputs: []string{"1.", "12.5", ".5", "1", "1.2.3"}},
+		{pattern: "[\\w-]+", inputs: []string{"a_b-c", "A9", "", "a b"}},
+		{pattern: "\\(\\*\\)|\\\\", inputs: []string{"(*)", "\\", "()", "*"}},
+		{pattern: "_+(ε|x)", inputs: []string{"_", "__x", "_ε", "x", ""}},
+		{pattern: "(a*)*", inputs: []string{"", "a", "aaaa", "b"}},
+		{pattern: "()", inputs: []string{"", "a"}},
+		{pattern: "[^a-y]\\p{Greek}+", inputs: []string{"zλ", "λλΩ", "aλ", "z", "\nλ"}},
+	}
+
+	for _, tc := range testCases {
+		compiled, err := Compile(tc.pattern)
+		if err != nil {
+			t.Errorf("Compile(%q) returned error %v", tc.pattern, err)
+			continue
+		}
+		expected := regexp.MustCompile("^(?:" + tc.pattern + ")$")
+		determinized := compiled.ToDFA()
+		for _, input := range tc.inputs {
+			want := expected.MatchString(input)
+			if got := compiled.ValidateStringSet([]rune(input)); got != want {
+				t.Errorf("Compile(%q).ValidateStringSet(%q) = %v; want %v", tc.pattern, input, got, want)
+			}
+			if got := determinized.ValidateString([]rune(input)); got != want {
+				t.Errorf("Compile(%q).ToDFA().ValidateString(%q) = %v; want %v", tc.pattern, input, got, want)
+			}
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		pos     int
+	}{
+		{pattern: "(ab", pos: 3},
+		{pattern: "ab)", pos: 2},
+		{pattern: "*a", pos: 0},
+		{pattern: "a|+", pos: 2},
+		{pattern: "[abc", pos: 0},
+		{pattern: "[z-a]", pos: 3},
+		{pattern: "ab\\", pos: 2},
+	}
+
+	for _, tc := range testCases {
+		_, err := Compile(tc.pattern)
+		var syntaxError *SyntaxError
+		if !errors.As(err, &syntaxError) {
+			t.Errorf("Compile(%q) error = %v; want a *SyntaxError", tc.pattern, err)
+			continue
+		}
+		if syntaxError.Pos != tc.pos {
+			t.Errorf("Compile(%q) error at position %d; want %d (%v)", tc.pattern, syntaxError.Pos, tc.pos, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package regex
+
+import (
+	"sort"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+	"github.com/dekuu5/FiniteStateMachine/nfa"
+)
+
+// EmptyLanguage is returned for automata that accept nothing, the syntax has no pattern for it
+const EmptyLanguage = "∅"
+
+/**
+ * This function converts the DFA into an equivalent regular expression by state elimination
+ * @param d: The DFA to convert
+ * @return A pattern accepted by Compile, or EmptyLanguage
+ */
+func FromDFA(d *dfa.DFA) string {
+	g := newGeneralized(d.States, d.StartState.StateName, d.AcceptStates)
+	for _, state := range d.States {
+		for _, symbol := range sortedSymbols(d.Transitions[state]) {
+			g.addEdge(state, d.Transitions[state][symbol], symbolExpr(symbol))
+		}
+		for _, edge := range d.ClassTransitions[state] {
+			g.addEdge(state, edge.Target, classExpr(edge.Class))
+		}
+	}
+	return g.eliminate()
+}
+
+/**
+ * This function converts the NFA into an equivalent regular expression by state elimination
+ * Epsilon moves become empty string edges
+ * @param n: The NFA to convert
+ * @return A pattern accepted by Compile, or EmptyLanguage
+ */
+func FromNFA(n *nfa.NFA) string {
+	epsilon := n.Epsilon
+	if epsilon == 0 {
+		epsilon = nfa.EpsilonSymbol
+	}
+	g := newGeneralized(n.States, n.StartState.StateName, n.AcceptStates)
+	for _, state := range n.States {
+		for _, symbol := range sortedSymbols(n.Transitions[state]) {
+			label := symbolExpr(symbol)
+			if symbol == epsilon {
+				label = epsilonExpr
+			}
+			for _, target := range n.Transitions[state][symbol] {
+				g.addEdge(state, target, label)
+			}
+		}
+		for _, edge := range n.ClassTransitions[state] {
+			for _, target := range edge.Targets {
+				g.addEdge(state, target, classExpr(edge.Class))
+			}
+		}
+	}
+	return g.eliminate()
+}
+
+/**
+ * generalized is an automaton whose edges are labelled with expressions
+ * State 0 is a new start state and state 1 a new accepting state, the states
+ * of the original automaton follow in their declared order
+ */
+type generalized struct {
+	index map[string]int
+	edges []map[int]*expr // edges[i][j] is the label of the edge from i to j
+}
+
+func newGeneralized(states []string, startState string, acceptStates []string) *generalized {
+	g := &generalized{index: make(map[string]int, len(states))}
+	g.edges = make([]map[int]*expr, len(states)+2)
+	for i := range g.edges {
+		g.edges[i] = make(map[int]*expr)
+	}
+	for i, state := range states {
+		g.index[state] = i + 2
+	}
+	g.edges[0][g.index[startState]] = epsilonExpr
+	for _, state := range acceptStates {
+		if i, exists := g.index[state]; exists {
+			g.edges[i][1] = alter(g.edges[i][1], epsilonExpr)
+		}
+	}
+	return g
+}
+
+// addEdge adds label as an alternative on the edge between the two states
+func (g *generalized) addEdge(from, to string, label *expr) {
+	i, fromExists := g.index[from]
+	j, toExists := g.index[to]
+	if fromExists && toExists {
+		g.edges[i][j] = alter(g.edges[i][j], label)
+	}
+}
+
+/**
+ * This function removes the original states one at a time, always picking the state
+ * with the fewest paths through it to keep the expression small
+ * @return The label left between the new start and accepting state
+ */
+func (g *generalized) eliminate() string {
+	remaining := make(map[int]bool, len(g.edges)-2)
+	for k := 2; k < len(g.edges); k++ {
+		remaining[k] = true
+	}
+
+	for len(remaining) > 0 {
+		best, bestCost := -1, 0
+		for k := range remaining {
+			cost := len(g.predecessors(k)) * len(g.edges[k])
+			if best == -1 || cost < bestCost || (cost == bestCost && k < best) {
+				best, bestCost = k, cost
+			}
+		}
+		g.remove(best)
+		delete(remaining, best)
+	}
+
+	result := g.edges[0][1]
+	if result == nil {
+		return EmptyLanguage
+	}
+	return result.String()
+}
+
+// predecessors returns the states other than k with an edge into k
+func (g *generalized) predecessors(k int) []int {
+	result := make([]int, 0)
+	for i, edges := range g.edges {
+		if _, exists := edges[k]; exists && i != k {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// remove reroutes every path i -> k -> j through a direct edge i -> j labelled R(i,k) R(k,k)* R(k,j)
+func (g *generalized) remove(k int) {
+	loop := star(g.edges[k][k])
+	successors := make([]int, 0, len(g.edges[k]))
+	for j := range g.edges[k] {
+		if j != k {
+			successors = append(successors, j)
+		}
+	}
+	sort.Ints(successors)
+
+	for _, i := range g.predecessors(k) {
+		for _, j := range successors {
+			g.edges[i][j] = alter(g.edges[i][j], concat(g.edges[i][k], loop, g.edges[k][j]))
+		}
+		delete(g.edges[i], k)
+	}
+	g.edges[k] = map[int]*expr{}
+}
+
+// sortedSymbols returns the keys of a transition row in rune order
+func sortedSymbols[T any](row map[rune]T) []rune {
+	symbols := make([]rune, 0, len(row))
+	for symbol := range row {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+	return symbols
+}
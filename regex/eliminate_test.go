@@ -0,0 +1,79 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/dekuu5/FiniteStateMachine/dfa"
+	"github.com/dekuu5/FiniteStateMachine/utils"
+)
+
+func TestFromNFA(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "a", expected: "a"},
+		{pattern: "a|b|c", expected: "[a-c]"},
+		{pattern: "ab|ab", expected: "ab"},
+		{pattern: "a|", expected: "a?"},
+		{pattern: "()", expected: "()"},
+		{pattern: "aa*", expected: "a+"},
+		{pattern: "(a|b)*", expected: "[ab]*"},
+		{pattern: "(a|b)*abb", expected: ""},
+		{pattern: "(ab|c)*d?", expected: ""},
+		{pattern: "\\(\\*\\)|[\\-\\]]", expected: ""},
+		{pattern: "_|x", expected: "[_x]"},
+		{pattern: "[0-4]|[5-9]", expected: "\\d"},
+		{pattern: "[^\"]*|\\p{Greek}", expected: ""},
+		{pattern: `[\d\D]`, expected: `[\x{0}-\x{10ffff}]`},
+	}
+
+	for _, tc := range testCases {
+		compiled := MustCompile(tc.pattern)
+		result := FromNFA(compiled)
+		if tc.expected != "" && result != tc.expected {
+			t.Errorf("FromNFA(%q) = %q; want %q", tc.pattern, result, tc.expected)
+		}
+		recompiled, err := Compile(result)
+		if err != nil {
+			t.Errorf("FromNFA(%q) = %q does not compile: %v", tc.pattern, result, err)
+			continue
+		}
+		if equivalent, counterexample := dfa.Equivalent(compiled, recompiled); !equivalent {
+			t.Errorf("FromNFA(%q) = %q differs on %q", tc.pattern, result, string(counterexample))
+		}
+	}
+}
+
+func TestFromDFA(t *testing.T) {
+	// strings over {a,b} with an even number of a's
+	evenA := dfa.Constructor(utils.FiniteAutomata{
+		States:       []string{"e", "o"},
+		Symbols:      []string{"a", "b"},
+		StartState:   "e",
+		AcceptStates: []string{"e"},
+		Transitions: map[string]map[string]string{
+			"e": {"a": "o", "b": "e"},
+			"o": {"a": "e", "b": "o"},
+		},
+	})
+	result := FromDFA(evenA)
+	recompiled, err := Compile(result)
+	if err != nil {
+		t.Fatalf("FromDFA() = %q does not compile: %v", result, err)
+	}
+	if equivalent, counterexample := dfa.Equivalent(evenA, recompiled); !equivalent {
+		t.Errorf("FromDFA() = %q differs on %q", result, string(counterexample))
+	}
+
+	empty := dfa.Constructor(utils.FiniteAutomata{
+		States:       []string{"s", "t"},
+		Symbols:      []string{"a"},
+		StartState:   "s",
+		AcceptStates: []string{"t"},
+		Transitions:  map[string]map[string]string{"s": {"a": "s"}},
+	})
+	if result := FromDFA(empty); result != EmptyLanguage {
+		t.Errorf("FromDFA() of the empty language = %q; want %q", result, EmptyLanguage)
+	}
+}
@@ -0,0 +1,267 @@
+package regex
+
+import (
+	"strings"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+)
+
+/**
+ * This file contains the expressions built by the state elimination in eliminate.go
+ * The constructors simplify as they go: the empty set is represented by nil and
+ * disappears from alternations, epsilon disappears from concatenations, duplicate
+ * alternatives are merged and single symbol alternatives are merged into one class
+ */
+
+type exprKind int
+
+const (
+	exprEpsilon exprKind = iota // the empty string
+	exprSet                     // one rune out of set
+	exprConcat                  // parts one after another
+	exprAlter                   // any of parts
+	exprStar                    // zero or more inner
+	exprPlus                    // one or more inner
+)
+
+type expr struct {
+	kind  exprKind
+	set   charclass.Class // exprSet
+	parts []*expr         // exprConcat and exprAlter
+	inner *expr           // exprStar and exprPlus
+}
+
+var epsilonExpr = &expr{kind: exprEpsilon}
+
+// symbolExpr matches the single rune c
+func symbolExpr(c rune) *expr {
+	return &expr{kind: exprSet, set: charclass.Single(c)}
+}
+
+// classExpr matches one rune out of the class
+func classExpr(class charclass.Class) *expr {
+	return &expr{kind: exprSet, set: class}
+}
+
+/**
+ * This function builds the alternation of the expressions
+ * @param exprs: The alternatives, nil ones match nothing and are dropped
+ * @return The simplified alternation, nil if every alternative is nil
+ */
+func alter(exprs ...*expr) *expr {
+	parts := make([]*expr, 0, len(exprs))
+	set := charclass.Class{}
+	hasEpsilon := false
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		members := []*expr{e}
+		if e.kind == exprAlter { // flatten nested alternations
+			members = e.parts
+		}
+		for _, member := range members {
+			switch member.kind {
+			case exprSet:
+				set = set.Union(member.set)
+			case exprEpsilon:
+				hasEpsilon = true
+			default:
+				parts = append(parts, member)
+			}
+		}
+	}
+	if !set.IsEmpty() {
+		parts = append([]*expr{{kind: exprSet, set: set}}, parts...)
+	}
+
+	// merge duplicates, comparing the rendered patterns
+	seen := make(map[string]bool, len(parts))
+	unique := parts[:0]
+	for _, part := range parts {
+		key := part.String()
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, part)
+		}
+	}
+	parts = subsume(unique, seen)
+
+	if hasEpsilon {
+		for i, part := range parts {
+			switch part.kind {
+			case exprStar: // epsilon is already matched by a starred alternative
+				hasEpsilon = false
+			case exprPlus: // ε|x+ is x*
+				parts[i] = &expr{kind: exprStar, inner: part.inner}
+				hasEpsilon = false
+			}
+		}
+		if hasEpsilon {
+			parts = append([]*expr{epsilonExpr}, parts...)
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return nil
+	case 1:
+		return parts[0]
+	}
+	return &expr{kind: exprAlter, parts: parts}
+}
+
+/**
+ * This function drops the alternatives that another alternative already matches:
+ * x and x+ are covered by x*, x by x+, and x|xx+ is x+
+ * @param parts: The unique alternatives
+ * @param rendered: The rendered patterns of the alternatives
+ * @return The remaining alternatives
+ */
+func subsume(parts []*expr, rendered map[string]bool) []*expr {
+	result := make([]*expr, 0, len(parts))
+	for _, part := range parts {
+		plus := &expr{kind: exprPlus, inner: part}
+		switch {
+		case part.kind != exprStar && rendered[star(part).String()]:
+			continue
+		case part.kind == exprPlus && rendered[star(part.inner).String()]:
+			continue
+		case part.kind != exprPlus && rendered[plus.String()]:
+			continue
+		case part.kind == exprConcat && len(part.parts) == 2 && part.parts[1].kind == exprPlus &&
+			part.parts[0].String() == part.parts[1].inner.String() &&
+			(rendered[part.parts[0].String()] || rendered[part.parts[1].String()]):
+			continue // xx+ is merged into the x or x+ alternative
+		case rendered[concat(part, plus).String()]:
+			part = plus // x|xx+ is x+
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+/**
+ * This function builds the concatenation of the expressions
+ * @param exprs: The parts, a nil part makes the whole concatenation nil
+ * @return The simplified concatenation
+ */
+func concat(exprs ...*expr) *expr {
+	parts := make([]*expr, 0, len(exprs))
+	for _, e := range exprs {
+		if e == nil {
+			return nil
+		}
+		members := []*expr{e}
+		if e.kind == exprConcat { // flatten nested concatenations
+			members = e.parts
+		}
+		for _, member := range members {
+			if member.kind == exprEpsilon {
+				continue
+			}
+			// x followed by x* is x+
+			if last := len(parts) - 1; last >= 0 && member.kind == exprStar && parts[last].String() == member.inner.String() {
+				parts[last] = &expr{kind: exprPlus, inner: member.inner}
+				continue
+			}
+			parts = append(parts, member)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return epsilonExpr
+	case 1:
+		return parts[0]
+	}
+	return &expr{kind: exprConcat, parts: parts}
+}
+
+// star builds e*, the star of nil or epsilon is epsilon
+func star(e *expr) *expr {
+	if e == nil || e.kind == exprEpsilon {
+		return epsilonExpr
+	}
+	switch e.kind {
+	case exprStar:
+		return e
+	case exprPlus:
+		return &expr{kind: exprStar, inner: e.inner}
+	case exprAlter:
+		if e.parts[0].kind == exprEpsilon { // (ε|x)* is x*
+			return star(alter(e.parts[1:]...))
+		}
+	}
+	return &expr{kind: exprStar, inner: e}
+}
+
+// String renders the expression in the syntax accepted by Compile
+func (e *expr) String() string {
+	var b strings.Builder
+	e.write(&b, 0)
+	return b.String()
+}
+
+// precedence levels used by write
+const (
+	levelAlter  = 0
+	levelConcat = 1
+	levelAtom   = 2
+)
+
+/**
+ * This function writes the expression, adding parentheses when it binds looser than the context
+ * @param b: The builder to write to
+ * @param level: The precedence level of the context
+ */
+func (e *expr) write(b *strings.Builder, level int) {
+	switch e.kind {
+	case exprEpsilon:
+		b.WriteString("()")
+	case exprSet:
+		b.WriteString(e.set.String()) // a literal for a single rune, a class otherwise
+	case exprStar, exprPlus:
+		e.inner.write(b, levelAtom)
+		if e.kind == exprStar {
+			b.WriteByte('*')
+		} else {
+			b.WriteByte('+')
+		}
+	case exprConcat:
+		if level > levelConcat {
+			b.WriteByte('(')
+		}
+		for _, part := range e.parts {
+			part.write(b, levelConcat)
+		}
+		if level > levelConcat {
+			b.WriteByte(')')
+		}
+	case exprAlter:
+		parts := e.parts
+		optional := parts[0].kind == exprEpsilon
+		if optional {
+			parts = parts[1:]
+		}
+		if len(parts) == 1 && optional {
+			parts[0].write(b, levelAtom)
+			b.WriteByte('?')
+			return
+		}
+		if level > levelAlter || optional {
+			b.WriteByte('(')
+		}
+		for i, part := range parts {
+			if i > 0 {
+				b.WriteByte('|')
+			}
+			part.write(b, levelAlter)
+		}
+		if level > levelAlter || optional {
+			b.WriteByte(')')
+		}
+		if optional {
+			b.WriteByte('?')
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package regex
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+)
+
+/**
+ * This file contains the parser that turns a pattern into a syntax tree
+ * The supported syntax is:
+ *   ab       concatenation
+ *   a|b      alternation
+ *   a* a+ a? zero or more, one or more, zero or one
+ *   (a)      grouping, () matches the empty string
+ *   [a-z0-9] character class, [^...] matches every other Unicode character
+ *   \d \w \s digits, word characters and whitespace, also inside classes
+ *   \p{L}    Unicode categories and scripts, see charclass.Parse for every escape
+ *   \n \t \r newline, tab and carriage return
+ *   \x       any other escaped character is taken literally, e.g. \* or \(
+ */
+
+// SyntaxError reports a malformed pattern
+type SyntaxError struct {
+	Pattern string
+	Pos     int // index of the offending rune in the pattern
+	Msg     string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("regex: %s at position %d in %q", e.Msg, e.Pos, e.Pattern)
+}
+
+// node is a node of the syntax tree
+type node interface{}
+
+type (
+	emptyNode  struct{}                        // matches the empty string
+	symbolNode struct{ class charclass.Class } // matches one rune out of the class
+	concatNode struct{ parts []node }          // matches the parts one after another
+	alterNode  struct{ parts []node }          // matches any of the parts
+	starNode   struct{ inner node }            // zero or more
+	plusNode   struct{ inner node }            // one or more
+	optNode    struct{ inner node }            // zero or one
+)
+
+type parser struct {
+	pattern []rune
+	pos     int
+}
+
+/**
+ * This function parses the pattern into a syntax tree
+ * @param pattern: The regular expression
+ * @return The root of the syntax tree or a *SyntaxError
+ */
+func parse(pattern string) (node, error) {
+	p := &parser{pattern: []rune(pattern)}
+	root, err := p.parseAlternation()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.pattern) { // the only way to stop early is an unmatched ')'
+		return nil, p.syntaxError("unmatched ')'")
+	}
+	return root, nil
+}
+
+// parseAlternation parses concat ('|' concat)*
+func (p *parser) parseAlternation() (node, error) {
+	parts := make([]node, 0, 1)
+	for {
+		part, err := p.parseConcatenation()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if !p.accept('|') {
+			break
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return alterNode{parts: parts}, nil
+}
+
+// parseConcatenation parses repeat*, an empty concatenation matches the empty string
+func (p *parser) parseConcatenation() (node, error) {
+	parts := make([]node, 0)
+	for p.pos < len(p.pattern) && p.peek() != '|' && p.peek() != ')' {
+		part, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	switch len(parts) {
+	case 0:
+		return emptyNode{}, nil
+	case 1:
+		return parts[0], nil
+	}
+	return concatNode{parts: parts}, nil
+}
+
+// parseRepeat parses atom ('*' | '+' | '?')*
+func (p *parser) parseRepeat() (node, error) {
+	atom, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for p.pos < len(p.pattern) {
+		switch p.peek() {
+		case '*':
+			atom = starNode{inner: atom}
+		case '+':
+			atom = plusNode{inner: atom}
+		case '?':
+			atom = optNode{inner: atom}
+		default:
+			return atom, nil
+		}
+		p.pos++
+	}
+	return atom, nil
+}
+
+// parseAtom parses a literal, an escape, a class or a group
+func (p *parser) parseAtom() (node, error) {
+	switch c := p.next(); c {
+	case '(':
+		inner, err := p.parseAlternation()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(')') {
+			return nil, p.syntaxError("missing ')'")
+		}
+		return inner, nil
+	case '[', '\\':
+		p.pos--
+		class, next, err := charclass.ParseAt(p.pattern, p.pos)
+		var classError *charclass.Error
+		if errors.As(err, &classError) {
+			p.pos = classError.Pos
+			return nil, p.syntaxError(classError.Msg)
+		}
+		p.pos = next
+		return symbolNode{class: class}, nil
+	case '*', '+', '?':
+		p.pos--
+		return nil, p.syntaxError(fmt.Sprintf("nothing to repeat before '%c'", c))
+	default:
+		return symbolNode{class: charclass.Single(c)}, nil
+	}
+}
+
+func (p *parser) peek() rune {
+	return p.pattern[p.pos]
+}
+
+func (p *parser) next() rune {
+	c := p.pattern[p.pos]
+	p.pos++
+	return c
+}
+
+// accept consumes c if it is the next rune
+func (p *parser) accept(c rune) bool {
+	if p.pos < len(p.pattern) && p.pattern[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) syntaxError(msg string) error {
+	return &SyntaxError{Pattern: string(p.pattern), Pos: p.pos, Msg: msg}
+}
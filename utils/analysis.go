@@ -0,0 +1,101 @@
+package utils
+
+// Analysis lists the states of an automaton that take no part in accepting
+// any string. A state can be in both lists.
+type Analysis struct {
+	Unreachable []string `json:"unreachable"` // states the start state cannot reach
+	Dead        []string `json:"dead"`        // states that cannot reach an accepting state
+}
+
+// Analyze finds the unreachable and dead states of an automaton from the
+// successors of every state, whatever the symbols of the transitions. Both
+// lists follow the order of states.
+func Analyze(states []string, successors map[string][]string, startState string, acceptStates []string) Analysis {
+	predecessors := make(map[string][]string, len(successors))
+	for state, targets := range successors {
+		for _, target := range targets {
+			predecessors[target] = append(predecessors[target], state)
+		}
+	}
+	reachable := search([]string{startState}, successors)
+	alive := search(acceptStates, predecessors)
+
+	analysis := Analysis{Unreachable: make([]string, 0), Dead: make([]string, 0)}
+	for _, state := range states {
+		if !reachable[state] {
+			analysis.Unreachable = append(analysis.Unreachable, state)
+		}
+		if !alive[state] {
+			analysis.Dead = append(analysis.Dead, state)
+		}
+	}
+	return analysis
+}
+
+// Both returns the states that are unreachable and dead
+func (a Analysis) Both() []string {
+	dead := make(map[string]bool, len(a.Dead))
+	for _, state := range a.Dead {
+		dead[state] = true
+	}
+	both := make([]string, 0)
+	for _, state := range a.Unreachable {
+		if dead[state] {
+			both = append(both, state)
+		}
+	}
+	return both
+}
+
+// Useless returns the set of the states that are unreachable or dead
+func (a Analysis) Useless() map[string]bool {
+	useless := make(map[string]bool, len(a.Unreachable)+len(a.Dead))
+	for _, state := range append(append([]string{}, a.Unreachable...), a.Dead...) {
+		useless[state] = true
+	}
+	return useless
+}
+
+// AddWarnings adds a warning to the report for every unreachable or dead
+// state, a state that is both gets a single warning
+func (a Analysis) AddWarnings(report *Report) {
+	both := make(map[string]bool)
+	for _, state := range a.Both() {
+		both[state] = true
+	}
+	for _, state := range a.Unreachable {
+		if both[state] {
+			report.Add(CodeUselessState, SeverityWarning, state, "", "State %s cannot be reached from the start state and cannot reach an accepting state", state)
+		} else {
+			report.Add(CodeUnreachableState, SeverityWarning, state, "", "State %s cannot be reached from the start state", state)
+		}
+	}
+	for _, state := range a.Dead {
+		if !both[state] {
+			report.Add(CodeDeadState, SeverityWarning, state, "", "State %s cannot reach an accepting state", state)
+		}
+	}
+}
+
+// search returns the states reachable from the given ones along the edges
+func search(from []string, edges map[string][]string) map[string]bool {
+	seen := make(map[string]bool, len(from))
+	stack := make([]string, 0, len(from))
+	for _, state := range from {
+		if !seen[state] {
+			seen[state] = true
+			stack = append(stack, state)
+		}
+	}
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range edges[state] {
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return seen
+}
@@ -1,8 +1,6 @@
 package utils
 
 import (
-	"encoding/json"
-	"io"
 	"log"
 	"os"
 )
@@ -13,25 +11,30 @@ type FiniteAutomata struct {
 	StartState   string                       `json:"start_state"`
 	AcceptStates []string                     `json:"accept_states"`
 	Transitions  map[string]map[string]string `json:"transitions"`
+	Alphabet     string                       `json:"alphabet,omitempty"` // AlphabetRunes when empty
+	Partial      bool                         `json:"partial,omitempty"`  // missing transitions go to an implicit dead state
 }
 
-func ReadJson(fileName string) FiniteAutomata {
-	file, err := os.Open(fileName)
-	if err != nil {
-		log.Fatalf("Error opening file: %v", err)
-		os.Exit(-1)
+// TokenTable returns an empty table for the tokens of the automaton, nil
+// unless its alphabet is made of tokens
+func (fa FiniteAutomata) TokenTable() *Tokens {
+	if fa.Alphabet == AlphabetTokens {
+		return NewTokens()
 	}
-	defer file.Close()
+	return nil
+}
 
-	fileBytes, err := io.ReadAll(file)
-	if err != nil {
-		log.Fatalf("Error reading file: %v", err)
-		os.Exit(-1)
-	}
-	var finiteAutomata FiniteAutomata
-	err = json.Unmarshal(fileBytes, &finiteAutomata)
+// ParseSymbol converts a symbol of the automaton to the rune used in the
+// transition tables, tokens is the table of the automaton from TokenTable
+func (fa FiniteAutomata) ParseSymbol(symbol string, tokens *Tokens) (rune, error) {
+	return parseSymbol(tokens, symbol)
+}
+
+// ReadJson reads the automaton from fileName and exits the program if it cannot
+func ReadJson(fileName string) FiniteAutomata {
+	finiteAutomata, err := LoadJson(fileName)
 	if err != nil {
-		log.Fatalf("Error parsing json: %v", err)
+		log.Fatalf("Error loading automaton: %v", err)
 		os.Exit(-1)
 	}
 	return finiteAutomata
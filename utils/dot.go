@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DotGraph collects the states and transitions of an automaton and writes
+// them as a Graphviz digraph. Accepting states are drawn as double circles,
+// the start state has an incoming arrow from an invisible node and all the
+// transitions between two states share one edge.
+type DotGraph struct {
+	name      string
+	states    []string
+	start     string
+	accepting map[string]bool
+	targets   map[string][]string            // targets of the edges leaving a state
+	labels    map[string]map[string][]string // labels of the edge between two states
+}
+
+// NewDotGraph returns a digraph with the states and no edges
+func NewDotGraph(name string, states []string, start string, accept []string) *DotGraph {
+	accepting := make(map[string]bool, len(accept))
+	for _, state := range accept {
+		accepting[state] = true
+	}
+	return &DotGraph{
+		name:      name,
+		states:    states,
+		start:     start,
+		accepting: accepting,
+		targets:   make(map[string][]string),
+		labels:    make(map[string]map[string][]string),
+	}
+}
+
+// AddLabel adds a label to the edge between two states, labels are shown in
+// the order they are added and a label already on the edge is not repeated
+func (g *DotGraph) AddLabel(from, to, label string) {
+	if g.labels[from] == nil {
+		g.labels[from] = make(map[string][]string)
+	}
+	labels, exists := g.labels[from][to]
+	if !exists {
+		g.targets[from] = append(g.targets[from], to)
+	}
+	for _, existing := range labels {
+		if existing == label {
+			return
+		}
+	}
+	g.labels[from][to] = append(labels, label)
+}
+
+// Write writes the digraph, the edges of each state sorted by target
+func (g *DotGraph) Write(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	start := FreshStateName("__start", g.states)
+
+	fmt.Fprintf(out, "digraph %s {\n", g.name)
+	fmt.Fprintln(out, "\trankdir=LR;")
+	fmt.Fprintf(out, "\t%s [shape=point, style=invis];\n", QuoteDot(start))
+	for _, state := range g.states {
+		shape := "circle"
+		if g.accepting[state] {
+			shape = "doublecircle"
+		}
+		fmt.Fprintf(out, "\t%s [shape=%s];\n", QuoteDot(state), shape)
+	}
+	fmt.Fprintf(out, "\t%s -> %s;\n", QuoteDot(start), QuoteDot(g.start))
+
+	for _, state := range g.states {
+		targets := append([]string{}, g.targets[state]...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			label := strings.Join(g.labels[state][target], ",")
+			fmt.Fprintf(out, "\t%s -> %s [label=%s];\n", QuoteDot(state), QuoteDot(target), QuoteDot(label))
+		}
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// DotSymbol returns the text of a symbol in an edge label, tokens is the
+// token table of the automaton or nil. Control characters and other
+// unprintable runes are shown escaped as in Go.
+func DotSymbol(tokens *Tokens, symbol rune) string {
+	if token, ok := tokens.Name(symbol); ok {
+		return token
+	}
+	if unicode.IsPrint(symbol) {
+		return string(symbol)
+	}
+	quoted := strconv.QuoteRune(symbol)
+	return quoted[1 : len(quoted)-1]
+}
+
+// EscapeDot escapes backslashes and double quotes so that the text can be
+// put between double quotes in a dot file
+func EscapeDot(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)
+}
+
+// QuoteDot returns the text as a double quoted dot ID
+func QuoteDot(text string) string {
+	return `"` + EscapeDot(text) + `"`
+}
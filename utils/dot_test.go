@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDotGraphWrite(t *testing.T) {
+	graph := NewDotGraph("DFA", []string{"__start", `q"1\`}, "__start", []string{`q"1\`})
+	graph.AddLabel("__start", `q"1\`, DotSymbol(nil, '"'))
+	graph.AddLabel("__start", `q"1\`, DotSymbol(nil, '\\'))
+	graph.AddLabel("__start", `q"1\`, DotSymbol(nil, '\n'))
+	graph.AddLabel("__start", `q"1\`, DotSymbol(nil, '"'))
+	graph.AddLabel(`q"1\`, `q"1\`, "[a-z]")
+
+	var out strings.Builder
+	if err := graph.Write(&out); err != nil {
+		t.Fatalf("Write() returned error %v", err)
+	}
+	expected := `digraph DFA {
+	rankdir=LR;
+	"__start1" [shape=point, style=invis];
+	"__start" [shape=circle];
+	"q\"1\\" [shape=doublecircle];
+	"__start1" -> "__start";
+	"__start" -> "q\"1\\" [label="\",\\,\\n"];
+	"q\"1\\" -> "q\"1\\" [label="[a-z]"];
+}
+`
+	if out.String() != expected {
+		t.Errorf("Write() =\n%s\nexpected\n%s", out.String(), expected)
+	}
+}
+
+func TestFreshStateName(t *testing.T) {
+	tests := []struct {
+		states   []string
+		expected string
+	}{
+		{[]string{"q0"}, "sink"},
+		{[]string{"sink", "q0"}, "sink1"},
+		{[]string{"sink", "sink1", "sink2"}, "sink3"},
+	}
+	for _, test := range tests {
+		if name := FreshStateName("sink", test.states); name != test.expected {
+			t.Errorf("FreshStateName(%q, %v) = %q, expected %q", "sink", test.states, name, test.expected)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package utils
+
+import "fmt"
+
+// Edge is a transition of a Graph. Epsilon edges read no symbol, the other
+// ones read Symbol, which stands for every symbol of the transition.
+type Edge struct {
+	Target  string
+	Symbol  rune
+	Epsilon bool
+}
+
+// Graph is the transition graph of an automaton, the edges of every state by
+// state name
+type Graph map[string][]Edge
+
+// Loop is the witness of an infinite language: the automaton accepts Prefix,
+// followed by Cycle repeated any number of times, followed by Suffix
+type Loop struct {
+	Prefix []rune `json:"prefix"`
+	Cycle  []rune `json:"cycle"`
+	Suffix []rune `json:"suffix"`
+}
+
+// ShortestWord returns the shortest word leading from one of the from states
+// to a state for which goal holds, together with that state. Ties are broken
+// by the order of the edges. ok is false when no such state can be reached.
+func (g Graph) ShortestWord(from []string, goal func(state string) bool) (word []rune, end string, ok bool) {
+	type step struct {
+		parent  string
+		symbol  rune
+		epsilon bool
+		root    bool
+	}
+	// breadth first search in which epsilon edges cost nothing: they are
+	// followed before the other states waiting at the same distance
+	distance := make(map[string]int)
+	steps := make(map[string]step)
+	deque := make([]string, 0, len(from))
+	for _, state := range from {
+		if _, seen := distance[state]; !seen {
+			distance[state] = 0
+			steps[state] = step{root: true}
+			deque = append(deque, state)
+		}
+	}
+
+	done := make(map[string]bool)
+	for len(deque) > 0 {
+		state := deque[0]
+		deque = deque[1:]
+		if done[state] {
+			continue
+		}
+		done[state] = true
+
+		if goal(state) {
+			word := make([]rune, 0, distance[state])
+			for current := state; !steps[current].root; current = steps[current].parent {
+				if !steps[current].epsilon {
+					word = append(word, steps[current].symbol)
+				}
+			}
+			for l, r := 0, len(word)-1; l < r; l, r = l+1, r-1 {
+				word[l], word[r] = word[r], word[l]
+			}
+			return word, state, true
+		}
+
+		for _, edge := range g[state] {
+			next := distance[state] + 1
+			if edge.Epsilon {
+				next--
+			}
+			if known, seen := distance[edge.Target]; seen && known <= next {
+				continue
+			}
+			distance[edge.Target] = next
+			steps[edge.Target] = step{parent: state, symbol: edge.Symbol, epsilon: edge.Epsilon}
+			if edge.Epsilon {
+				deque = append([]string{edge.Target}, deque...)
+			} else {
+				deque = append(deque, edge.Target)
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// FindLoop looks for a cycle that reads at least one symbol and goes through
+// states that are reachable from the start state and can reach an accepting
+// state, i.e. a witness that the language is infinite. states gives the order
+// in which the cycles are looked for. It returns nil for a finite language.
+func (g Graph) FindLoop(states []string, startState string, acceptStates []string) *Loop {
+	successors := make(map[string][]string, len(g))
+	for state, edges := range g {
+		for _, edge := range edges {
+			successors[state] = append(successors[state], edge.Target)
+		}
+	}
+	useless := Analyze(states, successors, startState, acceptStates).Useless()
+	accepting := make(map[string]bool, len(acceptStates))
+	for _, state := range acceptStates {
+		accepting[state] = true
+	}
+
+	component := g.Components(states, useless)
+	for _, state := range states {
+		if useless[state] {
+			continue
+		}
+		for _, edge := range g[state] {
+			if edge.Epsilon || useless[edge.Target] || component[edge.Target] != component[state] {
+				continue
+			}
+			// state reads the symbol and comes back to itself from the target
+			back, _, _ := g.ShortestWord([]string{edge.Target}, func(s string) bool { return s == state })
+			prefix, _, _ := g.ShortestWord([]string{startState}, func(s string) bool { return s == state })
+			suffix, _, _ := g.ShortestWord([]string{state}, func(s string) bool { return accepting[s] })
+			return &Loop{Prefix: prefix, Cycle: append([]rune{edge.Symbol}, back...), Suffix: suffix}
+		}
+	}
+	return nil
+}
+
+// Components numbers the strongly connected components of the graph without
+// the skipped states using Tarjan's algorithm
+func (g Graph) Components(states []string, skip map[string]bool) map[string]int {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	component := make(map[string]int)
+	stack := make([]string, 0)
+	count := 0
+
+	var visit func(state string)
+	visit = func(state string) {
+		index[state] = len(index)
+		low[state] = index[state]
+		stack = append(stack, state)
+		onStack[state] = true
+		for _, edge := range g[state] {
+			next := edge.Target
+			if skip[next] {
+				continue
+			}
+			if _, visited := index[next]; !visited {
+				visit(next)
+				low[state] = min(low[state], low[next])
+			} else if onStack[next] {
+				low[state] = min(low[state], index[next])
+			}
+		}
+		if low[state] == index[state] { // state is the root of a component
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component[top] = count
+				if top == state {
+					break
+				}
+			}
+			count++
+		}
+	}
+
+	for _, state := range states {
+		if _, visited := index[state]; !visited && !skip[state] {
+			visit(state)
+		}
+	}
+	return component
+}
+
+// FreshStateName returns base, or base followed by a number, so that the
+// name is not one of the existing states
+func FreshStateName(base string, states []string) string {
+	existing := make(map[string]bool, len(states))
+	for _, state := range states {
+		existing[state] = true
+	}
+	name := base
+	for i := 1; existing[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
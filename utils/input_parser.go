@@ -1,38 +1,70 @@
 package utils
 
 import (
-	"encoding/json"
-	"io"
 	"log"
 	"os"
+	"unicode/utf8"
 )
 
+// DefaultEpsilons are the spellings accepted as the epsilon symbol when the
+// automaton does not configure one
+var DefaultEpsilons = []string{"_", "ε"}
+
 type NFiniteAutomata struct {
 	States       []string                       `json:"states"`
 	Symbols      []string                       `json:"symbols"`
 	StartState   string                         `json:"start_state"`
 	AcceptStates []string                       `json:"accept_states"`
 	Transitions  map[string]map[string][]string `json:"transitions"`
+	Epsilon      string                         `json:"epsilon,omitempty"`  // empty means any of DefaultEpsilons
+	Alphabet     string                         `json:"alphabet,omitempty"` // AlphabetRunes when empty
 }
 
-func ReadJsonNfa(fileName string) NFiniteAutomata {
-	file, err := os.Open(fileName)
-	if err != nil {
-		log.Fatalf("Error opening file: %v", err)
-		os.Exit(-1)
+// TokenTable returns an empty table for the tokens of the automaton, nil
+// unless its alphabet is made of tokens
+func (fa NFiniteAutomata) TokenTable() *Tokens {
+	if fa.Alphabet == AlphabetTokens {
+		return NewTokens()
 	}
-	defer file.Close()
+	return nil
+}
 
-	fileBytes, err := io.ReadAll(file)
-	if err != nil {
-		log.Fatalf("Error reading file: %v", err)
-		os.Exit(-1)
+// ParseSymbol converts an input symbol of the automaton to the rune used in
+// the transition tables, tokens is the table of the automaton from TokenTable
+func (fa NFiniteAutomata) ParseSymbol(symbol string, tokens *Tokens) (rune, error) {
+	return parseSymbol(tokens, symbol)
+}
+
+// IsEpsilon reports whether the symbol is the epsilon symbol of the automaton
+func (fa NFiniteAutomata) IsEpsilon(symbol string) bool {
+	if fa.Epsilon != "" {
+		return symbol == fa.Epsilon
+	}
+	for _, epsilon := range DefaultEpsilons {
+		if symbol == epsilon {
+			return true
+		}
 	}
-	var finiteAutomata NFiniteAutomata
-	err = json.Unmarshal(fileBytes, &finiteAutomata)
+	return false
+}
+
+// ReadJsonNfa reads the automaton from fileName and exits the program if it cannot
+func ReadJsonNfa(fileName string) NFiniteAutomata {
+	finiteAutomata, err := LoadJsonNfa(fileName)
 	if err != nil {
-		log.Fatalf("Error parsing json: %v", err)
+		log.Fatalf("Error loading automaton: %v", err)
 		os.Exit(-1)
 	}
 	return finiteAutomata
 }
+
+// SymbolRune returns the rune of a symbol made of exactly one Unicode code
+// point, ok is false for empty, longer or invalid UTF-8 strings. A U+FFFD
+// written out in the symbol is a valid code point.
+func SymbolRune(symbol string) (r rune, ok bool) {
+	r, size := utf8.DecodeRuneInString(symbol)
+	if (r == utf8.RuneError && size <= 1) || size != len(symbol) {
+		return 0, false
+	}
+	return r, true
+}
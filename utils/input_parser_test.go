@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSymbolRune(t *testing.T) {
+	testCases := []struct {
+		symbol   string
+		expected rune
+		ok       bool
+	}{
+		{symbol: "a", expected: 'a', ok: true},
+		{symbol: "é", expected: 'é', ok: true},
+		{symbol: "�", expected: '�', ok: true},
+		{symbol: "", ok: false},
+		{symbol: "ab", ok: false},
+		{symbol: "\xce", ok: false},
+		{symbol: "��", ok: false},
+	}
+	for _, tc := range testCases {
+		if r, ok := SymbolRune(tc.symbol); r != tc.expected || ok != tc.ok {
+			t.Errorf("SymbolRune(%q) = %U, %v; want %U, %v", tc.symbol, r, ok, tc.expected, tc.ok)
+		}
+	}
+
+	// the lenient loader replaces invalid UTF-8 with U+FFFD, which is a valid symbol
+	finiteAutomata, err := ParseJson(strings.NewReader("{\"symbols\": [\"\xce\"]}"))
+	if err != nil || len(finiteAutomata.Symbols) != 1 || !ValidSymbol(AlphabetRunes, finiteAutomata.Symbols[0]) {
+		t.Errorf("ParseJson() = %q, %v; want a single valid symbol", finiteAutomata.Symbols, err)
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/dekuu5/FiniteStateMachine/charclass"
+)
+
+// IsClassLabel reports whether a symbol or transition key of the given
+// alphabet is a character class expression such as [a-z] or \d rather than a
+// single symbol. Classes are only available in the runes alphabet.
+func IsClassLabel(alphabet string, label string) bool {
+	return alphabet != AlphabetTokens && charclass.IsClass(label)
+}
+
+// LabelClass returns the runes matched by a symbol or class of the runes
+// alphabet, ok is false if the label is neither
+func LabelClass(label string) (charclass.Class, bool) {
+	if r, ok := SymbolRune(label); ok {
+		return charclass.Single(r), true
+	}
+	if !charclass.IsClass(label) {
+		return nil, false
+	}
+	class, err := charclass.Parse(label)
+	return class, err == nil
+}
+
+// parseLabel converts a symbol or class to the runes it matches, tokens are
+// registered in tokens when the alphabet is made of tokens
+func parseLabel(tokens *Tokens, label string) (charclass.Class, error) {
+	if tokens != nil {
+		r, err := parseSymbol(tokens, label)
+		if err != nil {
+			return nil, err
+		}
+		return charclass.Single(r), nil
+	}
+	if r, ok := SymbolRune(label); ok {
+		return charclass.Single(r), nil
+	}
+	if charclass.IsClass(label) {
+		return charclass.Parse(label)
+	}
+	return nil, fmt.Errorf("%q is neither a single character nor a character class", label)
+}
+
+// ParseLabel converts a symbol or a character class of the automaton to the
+// runes it matches, tokens is the table of the automaton from TokenTable
+func (fa FiniteAutomata) ParseLabel(label string, tokens *Tokens) (charclass.Class, error) {
+	return parseLabel(tokens, label)
+}
+
+// ParseLabel converts an input symbol or a character class of the automaton
+// to the runes it matches, tokens is the table of the automaton from TokenTable
+func (fa NFiniteAutomata) ParseLabel(label string, tokens *Tokens) (charclass.Class, error) {
+	return parseLabel(tokens, label)
+}
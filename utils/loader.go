@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// IOError is returned when the automaton cannot be opened or read
+type IOError struct {
+	Path string // empty when reading from an io.Reader
+	Err  error
+}
+
+func (e *IOError) Error() string {
+	return fmt.Sprintf("%sread error: %v", pathPrefix(e.Path), e.Err)
+}
+
+func (e *IOError) Unwrap() error { return e.Err }
+
+// SyntaxError is returned when the input is not well formed JSON
+type SyntaxError struct {
+	Path   string
+	Line   int // 1-based, 0 when unknown
+	Column int // 1-based, 0 when unknown
+	Err    error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s%sjson syntax error: %v", pathPrefix(e.Path), position(e.Line, e.Column), e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// SchemaError is returned when the JSON is well formed but does not describe
+// an automaton: a value has the wrong type or, when loading strictly, a
+// required field is missing or a field is unknown
+type SchemaError struct {
+	Path   string
+	Field  string // the offending field, e.g. "transitions.q0.a"
+	Line   int    // 1-based, 0 when unknown
+	Column int    // 1-based, 0 when unknown
+	Err    error
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s%sfield %q: %v", pathPrefix(e.Path), position(e.Line, e.Column), e.Field, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error { return e.Err }
+
+// ErrMissingField is wrapped by the SchemaError of a missing required field
+var ErrMissingField = errors.New("required field is missing")
+
+// ErrInvalidUTF8 is wrapped by the SyntaxError of input that is not valid
+// UTF-8 when loading strictly
+var ErrInvalidUTF8 = errors.New("invalid UTF-8 encoding")
+
+// requiredFields are the fields an automaton must declare when loading strictly
+var requiredFields = []string{"states", "symbols", "start_state", "accept_states"}
+
+// ParseJson reads a DFA from r. Like encoding/json it ignores unknown fields
+// and leaves missing ones empty, see ParseJsonStrict to reject them.
+func ParseJson(r io.Reader) (FiniteAutomata, error) {
+	var finiteAutomata FiniteAutomata
+	err := decode(r, "", false, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// ParseJsonStrict reads a DFA from r, rejecting input that is not valid
+// UTF-8, unknown fields and missing required fields
+func ParseJsonStrict(r io.Reader) (FiniteAutomata, error) {
+	var finiteAutomata FiniteAutomata
+	err := decode(r, "", true, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// LoadJson reads a DFA from the file at fileName, see ParseJson
+func LoadJson(fileName string) (FiniteAutomata, error) {
+	var finiteAutomata FiniteAutomata
+	err := decodeFile(fileName, false, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// LoadJsonStrict reads a DFA from the file at fileName, see ParseJsonStrict
+func LoadJsonStrict(fileName string) (FiniteAutomata, error) {
+	var finiteAutomata FiniteAutomata
+	err := decodeFile(fileName, true, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// ParseJsonNfa reads a NFA from r, see ParseJson
+func ParseJsonNfa(r io.Reader) (NFiniteAutomata, error) {
+	var finiteAutomata NFiniteAutomata
+	err := decode(r, "", false, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// ParseJsonNfaStrict reads a NFA from r, see ParseJsonStrict
+func ParseJsonNfaStrict(r io.Reader) (NFiniteAutomata, error) {
+	var finiteAutomata NFiniteAutomata
+	err := decode(r, "", true, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// LoadJsonNfa reads a NFA from the file at fileName, see ParseJson
+func LoadJsonNfa(fileName string) (NFiniteAutomata, error) {
+	var finiteAutomata NFiniteAutomata
+	err := decodeFile(fileName, false, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+// LoadJsonNfaStrict reads a NFA from the file at fileName, see ParseJsonStrict
+func LoadJsonNfaStrict(fileName string) (NFiniteAutomata, error) {
+	var finiteAutomata NFiniteAutomata
+	err := decodeFile(fileName, true, &finiteAutomata)
+	return finiteAutomata, err
+}
+
+func decodeFile(fileName string, strict bool, v interface{}) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return &IOError{Path: fileName, Err: err}
+	}
+	defer file.Close()
+	return decode(file, fileName, strict, v)
+}
+
+// decode reads a single JSON object from r into v, reporting problems as
+// IOError, SyntaxError or SchemaError. In strict mode invalid UTF-8, unknown
+// fields and missing required fields are errors too.
+func decode(r io.Reader, path string, strict bool, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &IOError{Path: path, Err: err}
+	}
+
+	// encoding/json would silently replace invalid UTF-8 in the symbols
+	if strict && !utf8.Valid(data) {
+		offset := 0
+		for offset < len(data) {
+			r, size := utf8.DecodeRune(data[offset:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			offset += size
+		}
+		line, column := lineColumn(data, int64(offset+1))
+		return &SyntaxError{Path: path, Line: line, Column: column, Err: ErrInvalidUTF8}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			line, column := lineColumn(data, syntaxErr.Offset)
+			return &SyntaxError{Path: path, Line: line, Column: column, Err: err}
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			line, column := lineColumn(data, int64(len(data)))
+			return &SyntaxError{Path: path, Line: line, Column: column, Err: io.ErrUnexpectedEOF}
+		case errors.As(err, &typeErr):
+			line, column := lineColumn(data, typeErr.Offset)
+			return &SchemaError{Path: path, Field: typeErr.Field, Line: line, Column: column, Err: err}
+		default:
+			if field, ok := unknownField(err); ok {
+				line, column := lineColumn(data, decoder.InputOffset())
+				return &SchemaError{Path: path, Field: field, Line: line, Column: column, Err: err}
+			}
+			return &SyntaxError{Path: path, Err: err}
+		}
+	}
+	if decoder.More() {
+		line, column := lineColumn(data, decoder.InputOffset())
+		return &SyntaxError{Path: path, Line: line, Column: column, Err: errors.New("unexpected data after the top-level object")}
+	}
+
+	if !strict {
+		return nil
+	}
+	// the struct cannot tell a missing field from an empty one
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return &SchemaError{Path: path, Err: err}
+	}
+	for _, field := range requiredFields {
+		if _, exists := fields[field]; !exists {
+			return &SchemaError{Path: path, Field: field, Err: ErrMissingField}
+		}
+	}
+	return nil
+}
+
+// unknownField returns the field named by the error DisallowUnknownFields
+// makes encoding/json return, which has no type of its own
+func unknownField(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	if !strings.HasPrefix(err.Error(), prefix) {
+		return "", false
+	}
+	field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), prefix))
+	if unquoteErr != nil {
+		return "", false
+	}
+	return field, true
+}
+
+// lineColumn converts the byte offset reported by encoding/json, which points
+// just past the offending byte, into a 1-based line and a column in runes
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:])
+	if column == 0 {
+		column = 1
+	}
+	return line, column
+}
+
+func pathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
+
+func position(line, column int) string {
+	if line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d: ", line, column)
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseJson(t *testing.T) {
+	valid := `{"states": ["q0"], "symbols": ["a"], "start_state": "q0", "accept_states": ["q0"], "transitions": {"q0": {"a": "q0"}}}`
+	finiteAutomata, err := ParseJson(strings.NewReader(valid))
+	if err != nil {
+		t.Fatalf("ParseJson() returned error %v", err)
+	}
+	if finiteAutomata.Transitions["q0"]["a"] != "q0" {
+		t.Errorf("ParseJson() = %+v; want the q0 -a-> q0 transition", finiteAutomata)
+	}
+
+	testCases := []struct {
+		name   string
+		strict bool
+		input  string
+		target interface{}
+		field  string
+		line   int
+		column int
+	}{
+		{name: "syntax", input: "{\n  \"states\": [\"q0\",]\n}", target: new(*SyntaxError), line: 2, column: 19},
+		{name: "truncated", input: "{\n  \"states\": [", target: new(*SyntaxError), line: 2, column: 13},
+		{name: "trailing data", input: valid + "\n{}", target: new(*SyntaxError), line: 2, column: 1},
+		{name: "wrong type", input: "{\n  \"states\": \"q0\"\n}", target: new(*SchemaError), field: "states", line: 2, column: 16},
+		{name: "unknown field", strict: true, input: "{\"states\": [], \"accept_state\": []}", target: new(*SchemaError), field: "accept_state", line: 1},
+		{name: "missing field", strict: true, input: `{"states": ["q0"], "symbols": ["a"], "accept_states": []}`, target: new(*SchemaError), field: "start_state"},
+	}
+
+	for _, tc := range testCases {
+		parse := ParseJson
+		if tc.strict {
+			parse = ParseJsonStrict
+		}
+		_, err := parse(strings.NewReader(tc.input))
+		switch target := tc.target.(type) {
+		case **SyntaxError:
+			if !errors.As(err, target) {
+				t.Errorf("%s: ParseJson() error = %v; want a *SyntaxError", tc.name, err)
+				continue
+			}
+			if (*target).Line != tc.line || (*target).Column != tc.column {
+				t.Errorf("%s: ParseJson() error at %d:%d; want %d:%d", tc.name, (*target).Line, (*target).Column, tc.line, tc.column)
+			}
+		case **SchemaError:
+			if !errors.As(err, target) {
+				t.Errorf("%s: ParseJson() error = %v; want a *SchemaError", tc.name, err)
+				continue
+			}
+			if (*target).Field != tc.field || (*target).Line != tc.line || (tc.column != 0 && (*target).Column != tc.column) {
+				t.Errorf("%s: ParseJson() error for field %q at %d:%d; want %q at %d:%d", tc.name, (*target).Field, (*target).Line, (*target).Column, tc.field, tc.line, tc.column)
+			}
+		}
+	}
+}
+
+func TestParseJsonLenient(t *testing.T) {
+	for _, input := range []string{
+		`{"comment": "one state", "states": ["q0"], "symbols": ["a"], "start_state": "q0", "accept_states": ["q0"]}`,
+		`{"states": ["q0"], "symbols": ["a"], "start_state": "q0"}`,
+		"{\"states\": [\"q0\"], \"symbols\": [\"\xce\"], \"start_state\": \"q0\"}",
+	} {
+		if _, err := ParseJson(strings.NewReader(input)); err != nil {
+			t.Errorf("ParseJson(%q) returned error %v", input, err)
+		}
+		if _, err := ParseJsonStrict(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseJsonStrict(%q) returned no error", input)
+		}
+	}
+}
+
+func TestUnknownField(t *testing.T) {
+	// pins the message of encoding/json, which has no error type for unknown fields
+	decoder := json.NewDecoder(strings.NewReader(`{"accept_state": []}`))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&FiniteAutomata{})
+	if field, ok := unknownField(err); !ok || field != "accept_state" {
+		t.Errorf("unknownField(%v) = %q, %v; want \"accept_state\", true", err, field, ok)
+	}
+	if _, ok := unknownField(errors.New("unexpected end of JSON input")); ok {
+		t.Errorf("unknownField() recognized an unrelated error")
+	}
+}
+
+func TestLoadJsonNfa(t *testing.T) {
+	if _, err := LoadJsonNfa("../test.json"); err != nil {
+		t.Errorf("LoadJsonNfa(test.json) returned error %v", err)
+	}
+
+	_, err := LoadJsonNfa("does-not-exist.json")
+	var ioErr *IOError
+	if !errors.As(err, &ioErr) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadJsonNfa() of a missing file error = %v; want an *IOError wrapping os.ErrNotExist", err)
+	}
+}
+
+func TestParseJsonStrictInvalidUTF8(t *testing.T) {
+	_, err := ParseJsonStrict(strings.NewReader("{\n  \"symbols\": [\"\xce\"]\n}"))
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("ParseJsonStrict() error = %v; want a *SyntaxError wrapping ErrInvalidUTF8", err)
+	}
+	if syntaxErr.Line != 2 || syntaxErr.Column != 16 {
+		t.Errorf("ParseJsonStrict() error at %d:%d; want 2:16", syntaxErr.Line, syntaxErr.Column)
+	}
+}
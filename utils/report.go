@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// Severity tells whether an issue makes the automaton invalid
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue codes reported by the validators of the dfa and nfa packages
+const (
+	CodeEmptyStates          = "empty-states"
+	CodeUnknownStartState    = "unknown-start-state"
+	CodeEmptySymbols         = "empty-symbols"
+	CodeInvalidSymbol        = "invalid-symbol"
+	CodeInvalidAlphabet      = "invalid-alphabet"
+	CodeEmptyAcceptStates    = "empty-accept-states"
+	CodeUnknownAcceptState   = "unknown-accept-state"
+	CodeUnknownSourceState   = "unknown-source-state"
+	CodeUnknownSymbol        = "unknown-symbol"
+	CodeUnknownTargetState   = "unknown-target-state"
+	CodeIncompleteTransition = "incomplete-transitions"
+	CodeInvalidClass         = "invalid-class"
+	CodeOverlappingLabels    = "overlapping-transitions"
+	CodeUnreachableState     = "unreachable-state"
+	CodeDeadState            = "dead-state"
+	CodeUselessState         = "unreachable-dead-state"
+)
+
+// Issue is a single problem found in an automaton
+type Issue struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	State    string   `json:"state,omitempty"`
+	Symbol   string   `json:"symbol,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Report lists every issue found while validating an automaton
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Add records an issue
+func (r *Report) Add(code string, severity Severity, state, symbol, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{
+		Code:     code,
+		Severity: severity,
+		State:    state,
+		Symbol:   symbol,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Valid reports whether the report has no errors, warnings are allowed
+func (r *Report) Valid() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTable writes the issues as an aligned table, one issue per line
+func (r *Report) WriteTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "SEVERITY\tCODE\tSTATE\tSYMBOL\tMESSAGE")
+	for _, issue := range r.Issues {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", issue.Severity, issue.Code, dash(issue.State), dash(issue.Symbol), issue.Message)
+	}
+	return table.Flush()
+}
+
+// WriteJSON writes the report as an indented JSON object
+func (r *Report) WriteJSON(w io.Writer) error {
+	report := *r
+	if report.Issues == nil {
+		report.Issues = []Issue{} // an empty list rather than null
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// SortedKeys returns the keys of a map in increasing order, used to report
+// the issues of a transition table in a stable order
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
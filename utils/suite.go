@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TestCase is an input string together with the verdict an automaton is
+// expected to give on it
+type TestCase struct {
+	Name   string `json:"name,omitempty"`
+	Input  string `json:"input"`
+	Accept bool   `json:"accept"`
+}
+
+// String returns the name of the case, by default the input prefixed with +
+// if it should be accepted and - if it should be rejected
+func (c TestCase) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	if c.Accept {
+		return "+" + c.Input
+	}
+	return "-" + c.Input
+}
+
+// ParseSuite reads test cases either as a JSON array of TestCase or as lines
+// of the form +input for strings to accept and -input for strings to reject.
+// Every JSON case must give "accept". Blank lines and lines starting with #
+// are skipped in the line format.
+func ParseSuite(data []byte) ([]TestCase, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		// the verdict is a pointer so that a missing "accept" does not mean reject
+		var decoded []struct {
+			Name   string `json:"name"`
+			Input  string `json:"input"`
+			Accept *bool  `json:"accept"`
+		}
+		if err := json.Unmarshal(trimmed, &decoded); err != nil {
+			return nil, fmt.Errorf("invalid JSON test suite: %v", err)
+		}
+		cases := make([]TestCase, len(decoded))
+		for i, c := range decoded {
+			if c.Accept == nil {
+				return nil, fmt.Errorf("case %d: the expected verdict \"accept\" is missing", i+1)
+			}
+			cases[i] = TestCase{Name: c.Name, Input: c.Input, Accept: *c.Accept}
+		}
+		return cases, nil
+	}
+
+	cases := make([]TestCase, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, "+"):
+			cases = append(cases, TestCase{Input: text[1:], Accept: true})
+		case strings.HasPrefix(text, "-"):
+			cases = append(cases, TestCase{Input: text[1:], Accept: false})
+		default:
+			return nil, fmt.Errorf("line %d: a test case starts with + or -", line)
+		}
+	}
+	return cases, scanner.Err()
+}
+
+// LoadSuite reads the test cases of a file, see ParseSuite
+func LoadSuite(fileName string) ([]TestCase, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	cases, err := ParseSuite(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fileName, err)
+	}
+	return cases, nil
+}
+
+// CaseResult is the outcome of running a test case
+type CaseResult struct {
+	Case     TestCase
+	Accepted bool  // the verdict of the automaton
+	Err      error // set when the input could not be read
+}
+
+// Passed reports whether the automaton gave the expected verdict
+func (r CaseResult) Passed() bool {
+	return r.Err == nil && r.Accepted == r.Case.Accept
+}
+
+// message explains why the case failed
+func (r CaseResult) message() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if r.Case.Accept {
+		return fmt.Sprintf("%q is rejected, expected it to be accepted", r.Case.Input)
+	}
+	return fmt.Sprintf("%q is accepted, expected it to be rejected", r.Case.Input)
+}
+
+// SuiteResult holds the results of every case of a test suite
+type SuiteResult struct {
+	Name    string
+	Results []CaseResult
+}
+
+// Failed returns the results of the cases that did not pass
+func (s *SuiteResult) Failed() []CaseResult {
+	failed := make([]CaseResult, 0)
+	for _, result := range s.Results {
+		if !result.Passed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// WriteSummary lists the failing cases followed by the number of passed and
+// failed cases
+func (s *SuiteResult) WriteSummary(w io.Writer) error {
+	failed := s.Failed()
+	for _, result := range failed {
+		if _, err := fmt.Fprintf(w, "FAIL %s: %s\n", result.Case, result.message()); err != nil {
+			return err
+		}
+	}
+	verdict := "PASS"
+	if len(failed) > 0 {
+		verdict = "FAIL"
+	}
+	_, err := fmt.Fprintf(w, "%s %s: %d passed, %d failed\n", verdict, s.Name, len(s.Results)-len(failed), len(failed))
+	return err
+}
+
+// junitSuites and the types below are the JUnit XML layout read by CI servers
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the results as a JUnit XML report, cases whose input
+// could not be read are reported as errors
+func (s *SuiteResult) WriteJUnit(w io.Writer) error {
+	suite := junitSuite{Name: s.Name, Tests: len(s.Results)}
+	for _, result := range s.Results {
+		c := junitCase{Name: result.Case.String(), ClassName: s.Name}
+		switch {
+		case result.Err != nil:
+			c.Error = &junitProblem{Message: result.message()}
+			suite.Errors++
+		case !result.Passed():
+			c.Failure = &junitProblem{Message: result.message()}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSuite(t *testing.T) {
+	want := []TestCase{{Input: "abb", Accept: true}, {Input: "ba"}, {Input: "", Accept: true}}
+
+	lines, err := ParseSuite([]byte("# cases of (a|b)*abb\n+abb\n-ba\n\n+\n"))
+	if err != nil || !reflect.DeepEqual(lines, want) {
+		t.Errorf("ParseSuite(lines) = %+v, %v; want %+v", lines, err, want)
+	}
+	cases, err := ParseSuite([]byte(`[{"input": "abb", "accept": true}, {"input": "ba", "accept": false}, {"input": "", "accept": true}]`))
+	if err != nil || !reflect.DeepEqual(cases, want) {
+		t.Errorf("ParseSuite(json) = %+v, %v; want %+v", cases, err, want)
+	}
+	if _, err := ParseSuite([]byte("+abb\nba\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ParseSuite() error = %v; want one about line 2", err)
+	}
+	if _, err := ParseSuite([]byte(`[{"input": "abb", "accept": true}, {"input": "ba"}]`)); err == nil || !strings.Contains(err.Error(), "case 2") {
+		t.Errorf("ParseSuite() error = %v; want one about the verdict of case 2", err)
+	}
+}
+
+func TestSuiteResult(t *testing.T) {
+	suite := SuiteResult{Name: "abb", Results: []CaseResult{
+		{Case: TestCase{Input: "abb", Accept: true}, Accepted: true},
+		{Case: TestCase{Input: "ba", Accept: true}},
+		{Case: TestCase{Name: "tokens", Input: "[", Accept: true}, Err: errors.New("bad tokens")},
+	}}
+	if failed := suite.Failed(); len(failed) != 2 {
+		t.Errorf("Failed() = %+v; want 2 results", failed)
+	}
+
+	var summary bytes.Buffer
+	if err := suite.WriteSummary(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(summary.String(), "FAIL +ba:") || !strings.HasSuffix(summary.String(), "FAIL abb: 1 passed, 2 failed\n") {
+		t.Errorf("WriteSummary() = %q", summary.String())
+	}
+
+	var junit bytes.Buffer
+	if err := suite.WriteJUnit(&junit); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{`<testsuite name="abb" tests="3" failures="1" errors="1">`, `<testcase name="+ba" classname="abb">`, `<error message="bad tokens">`} {
+		if !strings.Contains(junit.String(), part) {
+			t.Errorf("WriteJUnit() = %s; want it to contain %s", junit.String(), part)
+		}
+	}
+}
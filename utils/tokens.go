@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Alphabets an automaton can declare in its "alphabet" field
+const (
+	AlphabetRunes  = "runes"  // every symbol is a single Unicode character, the default
+	AlphabetTokens = "tokens" // every symbol is an arbitrary non-empty token such as "GET"
+)
+
+// Tokens are stored in the rune keyed transition tables of the dfa and nfa
+// packages by giving every distinct token of an automaton its own rune. The
+// runes are numbered past the last Unicode code point so that they cannot be
+// mistaken for characters, but only the Tokens of the automaton tells which
+// token a rune stands for.
+const (
+	firstTokenRune = unicode.MaxRune + 1
+	lastTokenRune  = math.MaxInt32
+)
+
+// ErrTooManyTokens is returned when every rune available for tokens is taken
+var ErrTooManyTokens = errors.New("too many distinct tokens")
+
+// Tokens maps the tokens of an automaton over a token alphabet to the runes
+// standing for them. A nil *Tokens is the runes alphabet, where every symbol
+// is its own character.
+type Tokens struct {
+	runes  map[string]rune
+	tokens []string
+}
+
+// NewTokens returns an empty token table
+func NewTokens() *Tokens {
+	return &Tokens{runes: make(map[string]rune)}
+}
+
+// Rune returns the rune standing for the token, registering it on first use
+func (t *Tokens) Rune(token string) (rune, error) {
+	if r, exists := t.runes[token]; exists {
+		return r, nil
+	}
+	if len(t.tokens) > lastTokenRune-firstTokenRune {
+		return 0, fmt.Errorf("cannot register %q: %w", token, ErrTooManyTokens)
+	}
+	r := firstTokenRune + rune(len(t.tokens))
+	t.runes[token] = r
+	t.tokens = append(t.tokens, token)
+	return r, nil
+}
+
+// Lookup returns the rune of a token that has been registered, without
+// registering unknown ones
+func (t *Tokens) Lookup(token string) (rune, bool) {
+	if t == nil {
+		return 0, false
+	}
+	r, exists := t.runes[token]
+	return r, exists
+}
+
+// Name returns the token a rune stands for, ok is false for other runes
+func (t *Tokens) Name(r rune) (token string, ok bool) {
+	if t == nil {
+		return "", false
+	}
+	i := int(r) - firstTokenRune
+	if i < 0 || i >= len(t.tokens) {
+		return "", false
+	}
+	return t.tokens[i], true
+}
+
+// Len returns the number of registered tokens
+func (t *Tokens) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.tokens)
+}
+
+// Runes converts the tokens of an input to their runes, ok is false if one
+// of them has not been registered
+func (t *Tokens) Runes(tokens []string) ([]rune, bool) {
+	runes := make([]rune, 0, len(tokens))
+	for _, token := range tokens {
+		r, exists := t.Lookup(token)
+		if !exists {
+			return nil, false
+		}
+		runes = append(runes, r)
+	}
+	return runes, true
+}
+
+// Symbol returns the symbol as written in the JSON: the token for token
+// runes, the character otherwise
+func (t *Tokens) Symbol(r rune) string {
+	if token, ok := t.Name(r); ok {
+		return token
+	}
+	return string(r)
+}
+
+// Format renders an input, separating the symbols with spaces when the
+// alphabet is made of tokens
+func (t *Tokens) Format(symbols []rune) string {
+	parts := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		parts = append(parts, t.Symbol(symbol))
+	}
+	if t != nil {
+		return strings.Join(parts, " ")
+	}
+	return strings.Join(parts, "")
+}
+
+// Merge returns a table holding the tokens of both tables. The tokens of t
+// keep their runes and mapping gives the rune in the merged table of every
+// token of other. Merging nil tables returns nil.
+func (t *Tokens) Merge(other *Tokens) (merged *Tokens, mapping map[rune]rune, err error) {
+	if t == nil && other == nil {
+		return nil, nil, nil
+	}
+	merged = NewTokens()
+	for _, token := range t.tokenList() {
+		if _, err := merged.Rune(token); err != nil {
+			return nil, nil, err
+		}
+	}
+	mapping = make(map[rune]rune, other.Len())
+	for i, token := range other.tokenList() {
+		r, err := merged.Rune(token)
+		if err != nil {
+			return nil, nil, err
+		}
+		mapping[firstTokenRune+rune(i)] = r
+	}
+	return merged, mapping, nil
+}
+
+// tokenList returns the registered tokens in the order of their runes
+func (t *Tokens) tokenList() []string {
+	if t == nil {
+		return nil
+	}
+	return t.tokens
+}
+
+// ParseTokens reads an input made of tokens, either as a JSON array of
+// strings or as whitespace separated words
+func ParseTokens(input string) ([]string, error) {
+	trimmed := strings.TrimSpace(input)
+	if strings.HasPrefix(trimmed, "[") {
+		var tokens []string
+		if err := json.Unmarshal([]byte(trimmed), &tokens); err != nil {
+			return nil, fmt.Errorf("parsing token array: %w", err)
+		}
+		return tokens, nil
+	}
+	return strings.Fields(trimmed), nil
+}
+
+// ValidSymbol reports whether the symbol is allowed in the given alphabet
+func ValidSymbol(alphabet string, symbol string) bool {
+	if alphabet == AlphabetTokens {
+		return symbol != ""
+	}
+	_, ok := SymbolRune(symbol)
+	return ok
+}
+
+// parseSymbol converts a symbol to its rune, registering it in tokens when
+// the alphabet is made of tokens
+func parseSymbol(tokens *Tokens, symbol string) (rune, error) {
+	if tokens != nil {
+		if symbol == "" {
+			return 0, errors.New("a token cannot be empty")
+		}
+		return tokens.Rune(symbol)
+	}
+	if r, ok := SymbolRune(symbol); ok {
+		return r, nil
+	}
+	return 0, fmt.Errorf("%q is not a single character", symbol)
+}
+
+// ValidAlphabet reports whether the alphabet is empty or one of the known alphabets
+func ValidAlphabet(alphabet string) bool {
+	return alphabet == "" || alphabet == AlphabetRunes || alphabet == AlphabetTokens
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	tokens := NewTokens()
+	get, err := tokens.Rune("GET")
+	if err != nil {
+		t.Fatalf("Rune(GET) returned error %v", err)
+	}
+	if again, _ := tokens.Rune("GET"); again != get {
+		t.Errorf("Rune(GET) = %U then %U; want the same rune", get, again)
+	}
+	post, _ := tokens.Rune("POST")
+	if name, ok := tokens.Name(post); !ok || name != "POST" {
+		t.Errorf("Name(%U) = %q, %v; want POST", post, name, ok)
+	}
+	if _, ok := tokens.Name('\U000F0000'); ok {
+		t.Errorf("Name(U+F0000) found a token; only registered runes are tokens")
+	}
+	if runes, known := tokens.Runes([]string{"POST", "GET"}); !known || !reflect.DeepEqual(runes, []rune{post, get}) {
+		t.Errorf("Runes(POST GET) = %v, %v; want %v", runes, known, []rune{post, get})
+	}
+	if _, known := tokens.Runes([]string{"PUT"}); known {
+		t.Errorf("Runes(PUT) registered an unknown token")
+	}
+	if formatted := tokens.Format([]rune{get, post}); formatted != "GET POST" {
+		t.Errorf("Format() = %q; want \"GET POST\"", formatted)
+	}
+
+	// a nil table is the runes alphabet
+	var runes *Tokens
+	if formatted := runes.Format([]rune("ab\U000F0000")); formatted != "ab\U000F0000" {
+		t.Errorf("nil Format() = %q; want the characters", formatted)
+	}
+	if _, known := runes.Runes([]string{"GET"}); known {
+		t.Errorf("nil Runes(GET) = known; want unknown")
+	}
+
+	other := NewTokens()
+	put, _ := other.Rune("PUT")
+	otherGet, _ := other.Rune("GET")
+	merged, mapping, err := tokens.Merge(other)
+	if err != nil {
+		t.Fatalf("Merge() returned error %v", err)
+	}
+	if r, _ := merged.Lookup("GET"); r != get || mapping[otherGet] != get {
+		t.Errorf("Merge() moved GET to %U, mapping %U; want %U", r, mapping[otherGet], get)
+	}
+	if name, _ := merged.Name(mapping[put]); name != "PUT" || mapping[put] == get || mapping[put] == post {
+		t.Errorf("Merge() mapped PUT to %U named %q; want a new rune", mapping[put], name)
+	}
+	if merged.Len() != 3 || tokens.Len() != 2 {
+		t.Errorf("Merge() left %d and %d tokens; want 3 in the merged table and 2 in the original", merged.Len(), tokens.Len())
+	}
+}
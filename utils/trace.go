@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Reasons for rejecting an input given in a Rejection
+const (
+	RejectMissingTransition = "missing-transition"
+	RejectNonAccepting      = "non-accepting"
+)
+
+// Step is one step of a traced run: the symbol read and the states entered
+// afterwards, a single state for a DFA and the active states for an NFA. The
+// first step has position -1 and holds the states before reading anything.
+type Step struct {
+	Position int      `json:"position"`
+	Symbol   string   `json:"symbol,omitempty"`
+	States   []string `json:"states"`
+}
+
+// Rejection explains why an input is rejected, either because no transition
+// can read the symbol at Position in States or because the run ended in
+// States, none of them accepting
+type Rejection struct {
+	Reason   string   `json:"reason"`
+	States   []string `json:"states"`
+	Symbol   string   `json:"symbol,omitempty"`
+	Position int      `json:"position"`
+	Message  string   `json:"message"`
+}
+
+// Trace records a run of an automaton on an input
+type Trace struct {
+	Input     string     `json:"input"`
+	Steps     []Step     `json:"steps"`
+	Accepted  bool       `json:"accepted"`
+	Rejection *Rejection `json:"rejection,omitempty"`
+
+	tokens *Tokens // names the symbols of an automaton over tokens
+}
+
+// NewTrace starts the trace of a run on the input in the given states, tokens
+// is the token table of the automaton or nil
+func NewTrace(tokens *Tokens, input []rune, states []string) *Trace {
+	return &Trace{Input: tokens.Format(input), Steps: []Step{{Position: -1, States: states}}, tokens: tokens}
+}
+
+// Enter records that the symbol at the position was read, leading to the states
+func (t *Trace) Enter(position int, symbol rune, states []string) {
+	t.Steps = append(t.Steps, Step{Position: position, Symbol: t.tokens.Symbol(symbol), States: states})
+}
+
+// Stuck rejects the input because no transition from the states reads the
+// symbol at the position
+func (t *Trace) Stuck(position int, symbol rune, states []string) *Trace {
+	name := t.tokens.Symbol(symbol)
+	message := fmt.Sprintf("no transition from %s on %q at position %d", describeStates(states), name, position)
+	if len(states) > 1 {
+		message = fmt.Sprintf("none of the %s has a transition on %q at position %d", describeStates(states), name, position)
+	}
+	t.Accepted = false
+	t.Rejection = &Rejection{Reason: RejectMissingTransition, States: states, Symbol: name, Position: position, Message: message}
+	return t
+}
+
+// End finishes the trace in the last states entered, accepting the input if
+// accepted is true and otherwise rejecting it for ending in non-accepting states
+func (t *Trace) End(accepted bool) *Trace {
+	t.Accepted = accepted
+	if !accepted {
+		states := t.Steps[len(t.Steps)-1].States
+		message := fmt.Sprintf("the input ended in the non-accepting %s", describeStates(states))
+		if len(states) == 0 {
+			message = "the input ended without any active state"
+		}
+		t.Rejection = &Rejection{Reason: RejectNonAccepting, States: states, Position: len(t.Steps) - 1, Message: message}
+	}
+	return t
+}
+
+// Explain returns the verdict of the run followed by the reason for a rejection
+func (t *Trace) Explain() string {
+	if t.Accepted {
+		return "accepted"
+	}
+	return "rejected: " + t.Rejection.Message
+}
+
+// WriteTable writes the steps as an aligned table followed by the verdict
+func (t *Trace) WriteTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "POSITION\tSYMBOL\tSTATES")
+	for _, step := range t.Steps {
+		position := "-"
+		if step.Position >= 0 {
+			position = fmt.Sprint(step.Position)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\n", position, dash(step.Symbol), dash(strings.Join(step.States, ", ")))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%q %s\n", t.Input, t.Explain())
+	return err
+}
+
+// WriteJSON writes the trace as an indented JSON object
+func (t *Trace) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
+
+// describeStates names one state as "state q0" and several as "states {q0, q1}"
+func describeStates(states []string) string {
+	if len(states) == 1 {
+		return "state " + states[0]
+	}
+	return "states {" + strings.Join(states, ", ") + "}"
+}